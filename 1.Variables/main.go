@@ -26,7 +26,7 @@ func main() {
 	d := 10.42       // float64
 
 	/*
-		a = true  // invalid → 'a' is already an int
+		a = true  // invalid → 'a' is already an int (proved in 43.Compile_Fixtures)
 	*/
 
 	// Updating existing variables → use "=" (not :=)
@@ -60,6 +60,7 @@ func main() {
 		- Value must be assigned at declaration (cannot be left uninitialized).
 		- Can be declared inside OR outside functions.
 		- Cannot be changed later.
+		- Typed vs untyped, iota, overflow errors: see 42.Untyped_Constants.
 	*/
 
 	const Z int = 11 // Typed constant
@@ -97,6 +97,9 @@ func main() {
 	- When a higher order function takes another function as a parameter or the function we pass to a higher order function function as an argument
 5. First Class Citizen
 	- Any data assigned to a variable is called a first class citizen
+6. Middleware
+	- A HOF that takes a function AND returns a wrapped one: func(Handler) Handler
+	- See 39.Middleware_Chain (logging, timing, retry, memoize around add/multiply)
 
 Why Important?
 - First-order functions = basic building blocks
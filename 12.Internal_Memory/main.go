@@ -73,4 +73,7 @@ Throughout the program:
 - Functions (`main`, `add`, `init`) are in Code Segment.
 - Stack is created/destroyed on each function call.
 - Heap is not used in this example (but GC would watch it if we used it).
+
+See where each of these really lives in a running process (Linux):
+    go run ./28.Memory_Map
 */
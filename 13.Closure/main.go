@@ -5,7 +5,7 @@ import "fmt"
 // -------------------------------
 // Global variables & constants
 // -------------------------------
-const a = 10 // "a" is a constant, stored in code segment (doesn't change)
+const a = 10 // "a" is a constant: not stored at all, the compiler pastes 10 wherever "a" is used (see 42.Untyped_Constants)
 var (
 	p = 100 // "p" is a global variable, stored in data segment
 )
@@ -77,6 +77,52 @@ func (u *User) birthday() {
 	fmt.Println("Happy Birthday,", u.Name, "🎉 Now Age:", u.Age)
 }
 
+// -------------------------------
+// INTERFACES & METHOD SETS
+// -------------------------------
+/*
+An interface lists method signatures. A type "satisfies" an interface
+when its METHOD SET contains every one of those methods.
+
+Method set rules:
+   - Method set of User  → only VALUE receiver methods
+                           (printDetails, call)
+   - Method set of *User → VALUE + POINTER receiver methods
+                           (printDetails, call, birthday)
+
+Why? A *User can always be dereferenced to get a User copy, but a
+User stored inside an interface is not addressable, so Go cannot
+take its address to call a pointer receiver method on it.
+
+Note: user1.birthday() still works on a plain variable because Go
+rewrites it to (&user1).birthday(). That shortcut only works for
+addressable values, never for values stored in an interface.
+*/
+
+// Printer is satisfied by anything that can print its details
+type Printer interface {
+	printDetails()
+}
+
+// Ager is satisfied by anything that can get one year older
+type Ager interface {
+	birthday()
+}
+
+// Compile-time assertions (checked by the compiler, cost nothing at runtime)
+var (
+	_ Printer = User{}       // ✅ printDetails has a value receiver
+	_ Printer = (*User)(nil) // ✅ *User also gets all value receiver methods
+	_ Ager    = (*User)(nil) // ✅ birthday has a pointer receiver
+	// _ Ager = User{}       // ❌ User does not implement Ager (method birthday has pointer receiver)
+)
+
+// describe accepts any Printer (User or *User both work)
+func describe(p Printer) {
+	fmt.Printf("Printer holds a %T\n", p)
+	p.printDetails()
+}
+
 // -------------------------------
 // main function
 // -------------------------------
@@ -103,6 +149,15 @@ func main() {
 	// Call pointer receiver method
 	user1.birthday()
 	user1.birthday()
+
+	// Interfaces: User and *User are both Printers
+	describe(user2)
+	describe(&user2)
+
+	// Only *User is an Ager, so we must store the address
+	var ager Ager = &user2
+	ager.birthday()
+	fmt.Println("user2 after birthday through Ager:", user2.Age) // original changed → 33
 }
 
 // -------------------------------
@@ -118,12 +173,17 @@ Go does 2 main things:
        ** Code Segment **
            User = type User struct { Name string; Age int }
            printUserDetails = func(u User) { ... }
-           (User).printDetails = func(u User) { ... } // User type object can only call this
-					 (User).call = func(u User) (a int) { ... } // User type and takes argument a
-					 (User).printDetails = func(u User) {...}
-           (*User).birthday = func(*User) { ... }
+           (User).printDetails = func(u User) { ... }    // value receiver
+           (User).call = func(u User, a int) { ... }      // value receiver + argument a
+           (*User).birthday = func(u *User) { ... }       // pointer receiver
+           describe = func(p Printer) { ... }
            main = func() { ... }
 
+   - The compiler also builds the METHOD SETS and checks every
+     interface assignment (including the `var _ Printer = User{}` lines):
+           User  → { call, printDetails }
+           *User → { birthday, call, printDetails }
+
    - Struct types and functions are "registered" in the code segment.
    - Constants and global variables are stored in the Data Segment.
    - The compiler performs **escape analysis** to see which variables
@@ -155,6 +215,14 @@ Go does 2 main things:
        Receiver Method -> Age: 32
        Happy Birthday, Sadik 🎉 Now Age: 31
        Happy Birthday, Sadik 🎉 Now Age: 32
+       Printer holds a main.User
+       Receiver Method -> Name: Al Sami
+       Receiver Method -> Age: 32
+       Printer holds a *main.User
+       Receiver Method -> Name: Al Sami
+       Receiver Method -> Age: 32
+       Happy Birthday, Al Sami 🎉 Now Age: 33
+       user2 after birthday through Ager: 33
 
 Value Receiver Methods:
   - Operate on a copy of the struct.
@@ -165,5 +233,11 @@ Value Receiver Methods:
 Pointer Receiver Methods:
   - Operate on the original struct.
   - Can modify fields (like birthday()).
+  - Only belong to the method set of *User, so only *User satisfies Ager.
+
+Mixing both receiver kinds on one type (like User here) is legal but
+easy to get wrong. Inspect any type's method sets with:
+       go run ./15.Reciever/methodset ./15.Reciever
+       go run ./15.Reciever/methodset ./15.Reciever User
 
 */
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// -------------------------------
+// METHOD SET CHECKER
+// -------------------------------
+/*
+Prints the method sets of named types in a package using go/types
+(the same type checker the compiler front end uses).
+
+Usage:
+    go run ./15.Reciever/methodset <package dir> [TypeName ...]
+
+Examples:
+    go run ./15.Reciever/methodset ./15.Reciever
+    go run ./15.Reciever/methodset ./15.Reciever User
+
+For every named (non-interface) type it shows:
+   - method set of T   (value receiver methods only)
+   - method set of *T  (value + pointer receiver methods)
+   - which interfaces of the package T and *T satisfy
+   - a warning when T mixes value and pointer receivers
+*/
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: methodset <package dir> [TypeName ...]")
+		os.Exit(2)
+	}
+
+	pkg, fset, err := loadPackage(os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "methodset:", err)
+		os.Exit(1)
+	}
+
+	names := os.Args[2:]
+	if len(names) == 0 {
+		names = pkg.Scope().Names() // already sorted
+	}
+
+	ifaces := interfacesOf(pkg)
+	found := false
+	for _, name := range names {
+		tn, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			if len(os.Args) > 2 {
+				fmt.Fprintf(os.Stderr, "methodset: %s is not a type in package %s\n", name, pkg.Name())
+			}
+			continue
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok || types.IsInterface(named) {
+			continue
+		}
+		found = true
+		report(fset, named, ifaces)
+	}
+
+	if !found {
+		os.Exit(1)
+	}
+}
+
+// loadPackage parses and type-checks all non-test .go files in dir
+func loadPackage(dir string) (*types.Package, *token.FileSet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check(files[0].Name.Name, fset, files, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pkg, fset, nil
+}
+
+// interfacesOf returns every named interface type declared in pkg
+func interfacesOf(pkg *types.Package) []*types.Named {
+	var out []*types.Named
+	for _, name := range pkg.Scope().Names() {
+		tn, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		if named, ok := tn.Type().(*types.Named); ok && types.IsInterface(named) {
+			out = append(out, named)
+		}
+	}
+	return out
+}
+
+func report(fset *token.FileSet, named *types.Named, ifaces []*types.Named) {
+	name := named.Obj().Name()
+	ptr := types.NewPointer(named)
+
+	fmt.Printf("type %s (%s)\n", name, fset.Position(named.Obj().Pos()))
+	fmt.Printf("  method set of %-8s %s\n", name+":", methodNames(types.NewMethodSet(named)))
+	fmt.Printf("  method set of %-8s %s\n", "*"+name+":", methodNames(types.NewMethodSet(ptr)))
+
+	for _, iface := range ifaces {
+		it := iface.Underlying().(*types.Interface)
+		var who []string
+		if types.Implements(named, it) {
+			who = append(who, name)
+		}
+		if types.Implements(ptr, it) {
+			who = append(who, "*"+name)
+		}
+		if len(who) == 0 {
+			who = append(who, "none")
+		}
+		fmt.Printf("  satisfies %-12s %s\n", iface.Obj().Name()+":", strings.Join(who, ", "))
+	}
+
+	// Receiver kinds come from the declared methods, not the method sets
+	var values, pointers []string
+	for i := 0; i < named.NumMethods(); i++ {
+		m := named.Method(i)
+		recv := m.Type().(*types.Signature).Recv()
+		if _, isPtr := recv.Type().(*types.Pointer); isPtr {
+			pointers = append(pointers, m.Name())
+		} else {
+			values = append(values, m.Name())
+		}
+	}
+	if len(values) > 0 && len(pointers) > 0 {
+		sort.Strings(values)
+		sort.Strings(pointers)
+		fmt.Printf("  ⚠ mixed receiver kinds: value %v, pointer %v\n", values, pointers)
+	}
+	fmt.Println()
+}
+
+func methodNames(ms *types.MethodSet) string {
+	if ms.Len() == 0 {
+		return "{}"
+	}
+	names := make([]string, ms.Len())
+	for i := 0; i < ms.Len(); i++ {
+		names[i] = ms.At(i).Obj().Name()
+	}
+	return "{ " + strings.Join(names, ", ") + " }"
+}
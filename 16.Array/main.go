@@ -17,6 +17,8 @@ func main() {
 	fmt.Println(arr)
 	fmt.Println(arr2)
 	fmt.Println(arr3)
+
+	// copies, ==, map keys, grids, [...] and slice conversions: 44.Array_Lab
 }
 
 /*
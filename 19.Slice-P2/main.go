@@ -40,4 +40,6 @@ func main() {
 
 /*
 slice underlying array rule => 1024 -> 100% increase after that 25% increase
+
+next container: maps, and how THEY grow => 46.Maps
 */
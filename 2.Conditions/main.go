@@ -14,7 +14,7 @@ func main() {
 	} else if age == 18 {
 		fmt.Println("You are just a teenager, not eligible to be married")
 	} else {
-		fmt.Println("You are an Alien")
+		fmt.Println("You are an Alien") // never runs: >, < and == cover every age (see 40.Rules_Engine)
 	}
 
 	// Another IF/ELSE example
@@ -70,6 +70,7 @@ func main() {
 		    code runs if none of the cases match
 		}
 	*/
+	// every other switch form (fallthrough, type switch, ...): 41.Switch_Lab
 	day := 3
 	switch day {
 	case 1:
@@ -2,7 +2,7 @@ package main
 
 import "fmt"
 
-// Variadic function
+// Variadic function (note: naming it print shadows the builtin print; see 37.Variadic_Lab)
 func print(numbers ...int) { // here numbers is the name of the parameter and it takes the number as a slice and then works with it, we use it to pass unknown number of elements into a slice
 	fmt.Println("emp: ", numbers, "len: ", len(numbers), "cap: ", cap(numbers))
 }
@@ -1,14 +1,249 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"strings"
+	"unsafe"
+)
 
+/*
+-------------------------------
+ SP (Stack Pointer) vs BP (Base Pointer)
+-------------------------------
+- SP points to the TOP of the stack (the lowest address in use).
+  Every call moves SP down to make room for the callee's frame.
+- BP points to the BASE of the current frame. Each frame saves the
+  caller's BP, so the saved BPs form a linked list of frames
+  (that is how debuggers and profilers walk the stack).
+- The RETURN ADDRESS (where to continue in the caller) is pushed by
+  the CALL instruction right above the callee's frame.
+
+   high addresses
+   ┌──────────────────────────┐
+   │ main's frame   (a, sum)  │ ← main's BP
+   ├──────────────────────────┤
+   │ return address into main │
+   │ saved BP (main's BP)     │
+   │ add's frame    (res)     │ ← add's BP
+   └──────────────────────────┘ ← SP
+   low addresses  (stack grows DOWN)
+
+Instead of trusting the drawing, this lesson asks the runtime:
+   - runtime.Callers       → the return addresses of every frame
+   - runtime.CallersFrames → function, file and line for each address
+   - runtime.Stack         → the goroutine's stack trace as text
+   - runtime/metrics       → goroutine starting stack size and total stack memory
+
+Run it:
+    go run ./21.Sp_VS_Bp
+*/
+
+// add gets its own stack frame only because it is not inlined.
+// Remove the directive and the compiler pastes add into main:
+// then there is no add frame at all (check with: go build -gcflags=-m).
+//
+//go:noinline
 func add(x int, y int) int {
 	res := x + y
+	printCallStack("inside add()", local{"main.add", "res", uintptr(unsafe.Pointer(&res))})
 	return res
 }
 
+// local is a variable we want to pin onto the call-stack diagram
+type local struct {
+	fn, name string
+	addr     uintptr
+}
+
+// printCallStack draws the frames of the calling goroutine, outermost first
+func printCallStack(title string, locals ...local) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs) // skip runtime.Callers and printCallStack
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var rows [][]string
+	for {
+		f, more := frames.Next()
+		row := []string{
+			f.Function,
+			fmt.Sprintf("  %s:%d", shortFile(f.File), f.Line),
+			fmt.Sprintf("  pc %#x = entry %#x + %#x", f.PC, f.Entry, f.PC-f.Entry),
+		}
+		for _, l := range locals {
+			if l.fn == f.Function {
+				row = append(row, fmt.Sprintf("  local %s @ %#x", l.name, l.addr))
+			}
+		}
+		rows = append(rows, row)
+		if !more {
+			break
+		}
+	}
+
+	const width = 48
+	line := strings.Repeat("─", width)
+	fmt.Printf("\n🔹 Call stack %s (generated at runtime)\n", title)
+	fmt.Println("   high addresses")
+	fmt.Println("   ┌" + line + "┐")
+	for i := len(rows) - 1; i >= 0; i-- { // outermost frame at the top
+		for _, text := range rows[i] {
+			fmt.Printf("   │ %-*s │\n", width-2, text)
+		}
+		if i > 0 {
+			fmt.Println("   ├" + line + "┤")
+		}
+	}
+	fmt.Println("   └" + line + "┘ ← SP")
+	fmt.Println("   low addresses")
+}
+
+func shortFile(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		if j := strings.LastIndex(path[:i], "/"); j >= 0 {
+			return path[j+1:]
+		}
+	}
+	return path
+}
+
+// -------------------------------
+// STACK GROWTH
+// -------------------------------
+/*
+A goroutine does NOT get a fixed 1–8 MB stack like an OS thread.
+It starts small (/gc/stack/starting-size:bytes, 2 KB by default).
+Every function prologue checks "is there room for my frame?".
+If not, the runtime:
+   1. allocates a stack TWICE as big,
+   2. copies every frame over,
+   3. fixes every pointer that points into the old stack,
+   4. frees the old stack.
+So the ADDRESS of a local variable can change while it is alive!
+*/
+
+// level is what we record at each recursion depth
+type level struct {
+	depth  int
+	buf    uintptr // address of this frame's big local
+	anchor uintptr // address of the goroutine's first local, right now
+}
+
+// offset is how far below the anchor buf is. Both addresses are taken in
+// the same call, so it stays valid when the stack is copied.
+func (l level) offset() int {
+	return int(l.anchor) - int(l.buf)
+}
+
+var sink byte
+
+// recurse uses a 1 KB local in every frame, so 64 levels need ~80 KB of stack
+//
+//go:noinline
+func recurse(depth, max int, anchor *byte, trace *[]level, stacks *uint64) {
+	var buf [1024]byte // large local → large frame
+	buf[depth%len(buf)] = byte(depth)
+
+	// anchor is a real pointer: when the stack is copied the runtime
+	// rewrites it, so its address tells us where the stack is NOW
+	*trace = append(*trace, level{depth, uintptr(unsafe.Pointer(&buf)), uintptr(unsafe.Pointer(anchor))})
+
+	if depth < max {
+		recurse(depth+1, max, anchor, trace, stacks)
+	} else {
+		*stacks = readUint64("/memory/classes/heap/stacks:bytes")
+		buf := make([]byte, 1<<16)
+		trace := buf[:runtime.Stack(buf, false)]
+		lines := strings.Split(string(trace), "\n")
+		fmt.Printf("\n🔹 runtime.Stack at depth %d (first frames of %d lines)\n", depth, len(lines))
+		for _, l := range lines[:7] {
+			fmt.Println("  ", l)
+		}
+		fmt.Println("   ...")
+	}
+	sink += buf[depth%len(buf)]
+}
+
+func readUint64(name string) uint64 {
+	s := []metrics.Sample{{Name: name}}
+	metrics.Read(s)
+	return s[0].Value.Uint64()
+}
+
+func stackGrowth() {
+	start := readUint64("/gc/stack/starting-size:bytes")
+	before := readUint64("/memory/classes/heap/stacks:bytes")
+
+	done := make(chan []level)
+	var during uint64
+	go func() { // a fresh goroutine → a fresh, small stack
+		var anchor byte
+		var trace []level
+		recurse(0, 64, &anchor, &trace, &during)
+		done <- trace
+	}()
+	trace := <-done
+
+	fmt.Println("\n🔹 Stack growth of a goroutine recursing 64 levels with a 1 KB local each")
+	fmt.Printf("   starting stack size: %d bytes (/gc/stack/starting-size:bytes)\n", start)
+	fmt.Printf("   %-6s %-16s %-16s %s\n", "depth", "&buf", "&anchor", "")
+
+	copies := 0
+	for i, l := range trace {
+		note := ""
+		if i > 0 && l.anchor != trace[i-1].anchor {
+			copies++
+			note = fmt.Sprintf("← stack copied (#%d), now ≥ %d KB", copies, start<<copies>>10)
+		}
+		if i > 0 && i < len(trace)-1 && note == "" && i%8 != 0 {
+			continue // only print interesting rows
+		}
+		fmt.Printf("   %-6d %#-16x %#-16x %s\n", l.depth, l.buf, l.anchor, note)
+	}
+	// &anchor - &buf is read in ONE call, so it is the depth of buf below
+	// the goroutine's first local, and a stack copy does not change it.
+	// Subtracting raw &buf of two levels would mix two different stacks
+	// whenever a copy happened in between.
+	if frame := trace[1].offset() - trace[0].offset(); frame > 0 {
+		fmt.Printf("   frame size ≈ %d bytes (depth of &buf below &anchor, level 1 - level 0)\n", frame)
+	}
+	fmt.Printf("   stack copies: %d → final stack ≈ %d KB\n", copies, start<<copies>>10)
+	fmt.Printf("   stack memory in use: %d KB before, %d KB at the deepest call (/memory/classes/heap/stacks:bytes)\n",
+		before>>10, during>>10)
+}
+
 func main() {
 	a := 10
 	sum := add(a, 4)
 	fmt.Println(sum)
+
+	stackGrowth()
 }
+
+/*
+-------------------------------
+ READING THE OUTPUT
+-------------------------------
+1. Call stack inside add()
+   - Frames are listed from main (top, higher addresses) down to add.
+   - runtime.Callers stores RETURN ADDRESSES (the instruction right after
+     each CALL). CallersFrames reports pc = return address - 1, so the
+     file:line is the line of the call itself, not the line after it.
+     pc - entry = how far into the function the call happened.
+   - runtime.main and runtime.goexit sit above main.main: the runtime
+     calls your main, and goexit is the fake "caller" of every goroutine.
+   - local res @ ... is an address on the goroutine stack.
+
+2. Stack growth
+   - &buf goes DOWN by ~1 KB per depth → the stack grows toward lower addresses.
+   - &anchor CHANGES a few times: the whole stack was copied to a bigger block.
+     The goroutine never noticed — its pointers were rewritten by the runtime.
+   - Each copy doubles the stack: 2 KB → 4 → 8 → 16 → 32 → 64 KB.
+   - After the goroutine exits, the GC can shrink or free its stack.
+
+3. Why this matters
+   - Goroutines are cheap because stacks start tiny and grow on demand.
+   - Pointers into a goroutine stack must never be hidden from the
+     runtime (e.g. as uintptr) across a call — the stack can move.
+*/
@@ -1,20 +1,203 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 )
 
-func worker(name string) {
-	for {
-		fmt.Println(name, time.Now().Format("15:04:05.000"))
+/*
+====================================================
+🔹 CONTEXT SWITCHING ON ONE CORE
+====================================================
+
+GOMAXPROCS(1): only one goroutine runs at a time. Two workers that never
+block still both make progress, because the runtime preempts a goroutine
+that has run for ~10ms and switches to the other one (time-slicing).
+
+Each worker prints lines until its context is done, then returns how many
+it printed. The counts show how fair the time-slicing was:
+
+    Worker-1     1262284 lines   49.0%
+    Worker-2     1313477 lines   51.0%
+
+Workers stop on:
+   - the -d timeout         context.WithTimeout
+   - Ctrl+C (SIGINT)        signal.NotifyContext
+
+Run it:
+    go run ./22.Context_Switching_Concurrency            (50ms, every line printed)
+    go run ./22.Context_Switching_Concurrency -q -d 1s   (counts only: -q discards the lines)
+    go run ./22.Context_Switching_Concurrency -q -d 1m   (stop it with Ctrl+C)
+
+The expected results are tests in main_test.go:
+    go test ./22.Context_Switching_Concurrency/...
+====================================================
+*/
+
+// show prints one observation
+func show(name, got string) { fmt.Printf("  %-42s %s\n", name, got) }
+
+// worker prints its name and the time to w until ctx is done, and
+// returns how many lines it printed
+func worker(ctx context.Context, name string, w io.Writer) int {
+	n := 0
+	for ctx.Err() == nil {
+		fmt.Fprintln(w, name, time.Now().Format("15:04:05.000"))
+		n++
+	}
+	return n
+}
+
+// run starts one worker per name, waits for all of them to stop and
+// returns their line counts, in the order of names
+func run(ctx context.Context, w io.Writer, names ...string) []int {
+	counts := make([]int, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counts[i] = worker(ctx, name, w) // own index: no lock needed
+		}()
 	}
+	wg.Wait()
+	return counts
+}
+
+// syncWriter lets several goroutines write to one io.Writer. os.Stdout
+// is already safe for that; a bytes.Buffer is not.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// ----------------------------
+// 1. Time-slicing under GOMAXPROCS(1)
+// ----------------------------
+func timeSlicing(ctx context.Context, w io.Writer, d time.Duration) {
+	fmt.Printf("🔹 1. Two busy workers, GOMAXPROCS(%d), for %v\n", runtime.GOMAXPROCS(0), d)
+
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	start := time.Now()
+	names := []string{"Worker-1", "Worker-2"}
+	counts := run(ctx, w, names...)
+	took := time.Since(start)
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	for i, name := range names {
+		fmt.Printf("  %s  %10d lines  %5.1f%%\n", name, counts[i], 100*float64(counts[i])/float64(max(total, 1)))
+	}
+
+	stoppedBy := "timeout"
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		stoppedBy = "interrupt (Ctrl+C)"
+	}
+	fmt.Printf("  stopped by %s after %v\n", stoppedBy, took.Round(time.Millisecond))
+
+	// min/max: 1.0 = perfectly fair, 0 = one worker starved. It changes
+	// from run to run, so it is only shown.
+	fairness := float64(min(counts[0], counts[1])) / float64(max(counts[0], counts[1], 1))
+	show("fairness (min/max lines)", fmt.Sprintf("%.2f", fairness))
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. The injected writer
+// ----------------------------
+func injectedWriter() {
+	fmt.Println("🔹 2. Output goes to any io.Writer: here a buffer")
+
+	var buf bytes.Buffer
+	// longer than one ~10ms time slice, so both get a turn
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	counts := run(ctx, &syncWriter{w: &buf}, "A", "B")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	perName := map[string]int{}
+	for _, line := range lines {
+		name, _, _ := strings.Cut(line, " ")
+		perName[name]++
+	}
+	show("lines returned by the workers", fmt.Sprintf("A=%d B=%d", counts[0], counts[1]))
+	show("lines found in the buffer", fmt.Sprintf("A=%d B=%d", perName["A"], perName["B"]))
+	show("first line", lines[0])
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. Cancel stops them right away
+// ----------------------------
+func cancelled() {
+	fmt.Println("🔹 3. An already cancelled context: workers return at once")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	counts := run(ctx, io.Discard, "Worker-1", "Worker-2")
+	show("lines printed", fmt.Sprint(counts))
+	fmt.Println()
 }
 
 func main() {
+	d := flag.Duration("d", 50*time.Millisecond, "how long the workers run")
+	quiet := flag.Bool("q", false, "discard the lines and print only the counts")
+	flag.Parse()
+
 	runtime.GOMAXPROCS(1)
-	go worker("Worker-1")
-	go worker("Worker-2")
-	select {}
+
+	// Ctrl+C cancels ctx instead of killing the process, so the summary
+	// is still printed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+
+	var w io.Writer = os.Stdout
+	if *quiet {
+		w = io.Discard
+	}
+	timeSlicing(ctx, w, *d)
+	injectedWriter()
+	cancelled()
+	stop()
 }
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) GOMAXPROCS(1) = one goroutine runs at a time. Busy goroutines are
+   preempted after ~10ms, so both workers get a similar share of lines.
+
+2) A worker that loops forever cannot be stopped from outside. Give it a
+   context and check ctx.Err() (or select on ctx.Done()) in the loop.
+
+3) signal.NotifyContext turns Ctrl+C into a cancelled context: the
+   program shuts down cleanly instead of being killed mid-line.
+
+4) Take the io.Writer as a parameter: os.Stdout to watch it, io.Discard
+   to measure it, a buffer to check it.
+
+5) `select {}` blocks main forever: nothing can end the program cleanly.
+   wg.Wait() + a context ends it when the work is done.
+====================================================
+*/
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"go_projects/48.Leak_Check/leakcheck"
+)
+
+// run waits for its workers, so none of them outlives it
+func TestRunLeavesNoGoroutines(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	run(ctx, io.Discard, "Worker-1", "Worker-2")
+}
+
+func TestBothWorkersRun(t *testing.T) {
+	// one core, as in main: the workers only both run if the runtime
+	// preempts them
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	// longer than one ~10ms time slice, so both get a turn. How fair the
+	// split is changes from run to run: it is not checked.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if counts := run(ctx, io.Discard, "Worker-1", "Worker-2"); counts[0] == 0 || counts[1] == 0 {
+		t.Errorf("counts = %v, want both workers to print", counts)
+	}
+}
+
+func TestInjectedWriter(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	counts := run(ctx, &syncWriter{w: &buf}, "A", "B")
+
+	perName := map[string]int{}
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		name, clock, _ := strings.Cut(line, " ")
+		perName[name]++
+		if _, err := time.Parse("15:04:05.000", clock); err != nil {
+			t.Fatalf("line %q is not \"name hh:mm:ss.mmm\"", line)
+		}
+	}
+	if perName["A"] != counts[0] || perName["B"] != counts[1] {
+		t.Errorf("buffer has A=%d B=%d lines, workers returned %v", perName["A"], perName["B"], counts)
+	}
+}
+
+func TestCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if counts := run(ctx, io.Discard, "Worker-1", "Worker-2"); counts[0] != 0 || counts[1] != 0 {
+		t.Errorf("counts = %v, want no line after cancel", counts)
+	}
+}
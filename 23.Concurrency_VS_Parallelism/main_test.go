@@ -0,0 +1,22 @@
+package main
+
+import (
+	"runtime"
+	"testing"
+
+	"go_projects/48.Leak_Check/leakcheck"
+)
+
+// every worker sends on done, so every worker has returned when
+// runWithProcs does
+func TestRunWithProcsLeavesNoGoroutines(t *testing.T) {
+	defer leakcheck.Check(t)()
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0)) // runWithProcs changes it
+
+	for _, procs := range []int{1, runtime.NumCPU()} {
+		runWithProcs(procs)
+		if got := runtime.GOMAXPROCS(0); got != procs {
+			t.Errorf("GOMAXPROCS = %d after runWithProcs(%d)", got, procs)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"os/exec"
+	"testing"
+
+	"go_projects/48.Leak_Check/leakcheck"
+)
+
+// both ping-pong goroutines end after n rounds
+func TestGoroutineSwitchesLeavesNoGoroutines(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	if d := goroutineSwitches(10_000); d <= 0 {
+		t.Errorf("goroutineSwitches took %v", d)
+	}
+}
+
+// exec.Cmd.Run waits for its own helper goroutines
+func TestProcessSwitchesLeavesNoGoroutines(t *testing.T) {
+	if _, err := exec.LookPath("true"); err != nil {
+		t.Skip("no `true` command:", err)
+	}
+	defer leakcheck.Check(t)()
+
+	if d := processSwitches(5); d <= 0 {
+		t.Errorf("processSwitches took %v", d)
+	}
+}
@@ -172,4 +172,7 @@ D) Inspect assembly for where ret slot is filled and where defer call occurs
 - Assembly:     go tool compile -S defer_closure.go > asm.txt
 - Disasm:       go build -o deferprog defer_closure.go && go tool objdump -s main.calc deferprog
 
+12) Where named results matter in real code (recover → error, error wrapping, Close errors)
+    go run ./32.Named_Returns_Recover
+
 */
@@ -141,6 +141,7 @@ func examplePanicDefer() {
 }
 
 // Example: defer + recover = gracefully handle panic
+// (only in THIS goroutine — see 33.Safe_Go for child goroutines and HTTP handlers)
 func exampleRecoverDefer() {
 	defer func() {
 		if r := recover(); r != nil {
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"unsafe"
+)
+
+// ----------------------------
+// THEORY QUICK NOTES
+// ----------------------------
+/*
+17.Pointers says: "If we used [3]int → full array gets copied into
+function stack frame". 15.Reciever's printUserDetails(usr User) also
+copies the whole struct. This lesson MEASURES that cost.
+
+Three ways to hand data to a function:
+   1. By value    → func f(a [N]int64)   → whole array copied (N*8 bytes)
+   2. By pointer  → func f(a *[N]int64)  → only 8 bytes copied (the address)
+   3. By slice    → func f(s []int64)    → 24 bytes copied (ptr, len, cap)
+
+But a pointer is NOT always cheaper:
+   - If the callee lets the pointer "escape" (stores it in a global,
+     sends it on a channel, keeps it in a struct that outlives the call)
+     the compiler must move the value to the HEAP.
+   - Heap allocation = allocator call + zeroing + GC work later.
+   - For small values that costs MORE than simply copying 8–64 bytes.
+
+Run it:
+    go run ./27.Copy_Cost
+    go run ./27.Copy_Cost -benchtime=500ms
+
+See the escape decisions behind the numbers:
+    go build -o /dev/null -gcflags=-m ./27.Copy_Cost 2>&1 | grep "moved to heap"
+*/
+
+// ----------------------------
+// Array types (8 bytes → 64 KB)
+// ----------------------------
+type (
+	Arr8   [1]int64    // 8 B
+	Arr64  [8]int64    // 64 B
+	Arr512 [64]int64   // 512 B
+	Arr4K  [512]int64  // 4 KB
+	Arr32K [4096]int64 // 32 KB
+	Arr64K [8192]int64 // 64 KB
+)
+
+// ----------------------------
+// Struct types (8 bytes → 64 KB)
+// ----------------------------
+// Every struct starts with an int64 ID so the callee can read it.
+type (
+	User8  struct{ ID int64 }
+	User64 struct {
+		ID, Age int64
+		Name    [48]byte
+	}
+	User512 struct {
+		ID, Age int64
+		Bio     [496]byte
+	}
+	User4K struct {
+		ID, Age int64
+		Bio     [4080]byte
+	}
+	User32K struct {
+		ID, Age int64
+		Bio     [32752]byte
+	}
+	User64K struct {
+		ID, Age int64
+		Bio     [65520]byte
+	}
+)
+
+// ----------------------------
+// Callees (never inlined, so the copy really happens)
+// ----------------------------
+
+// sink is a global → anything stored here escapes to the heap
+var sink unsafe.Pointer
+
+// byValue receives a full copy of v in its stack frame
+//
+//go:noinline
+func byValue[T any](v T) int64 {
+	return *(*int64)(unsafe.Pointer(&v)) // read the first word
+}
+
+// byPointer receives only the address; p does not escape
+//
+//go:noinline
+func byPointer[T any](p *T) int64 {
+	return *(*int64)(unsafe.Pointer(p))
+}
+
+// byPointerEscape keeps the address in a global → caller's value moves to heap
+//
+//go:noinline
+func byPointerEscape[T any](p *T) int64 {
+	sink = unsafe.Pointer(p)
+	return *(*int64)(unsafe.Pointer(p))
+}
+
+// bySlice receives a slice header (ptr, len, cap)
+//
+//go:noinline
+func bySlice(s []int64) int64 {
+	return s[0]
+}
+
+// ----------------------------
+// Benchmarks
+// ----------------------------
+/*
+Every iteration builds a FRESH value (like real code building a request
+or a user record) and hands it to the callee. Only the way it is handed
+over changes between the cases.
+*/
+
+func benchValue[T any](b *testing.B) {
+	var total int64
+	for i := 0; i < b.N; i++ {
+		var v T
+		*(*int64)(unsafe.Pointer(&v)) = int64(i)
+		total += byValue(v)
+	}
+	keep(total)
+}
+
+func benchPointer[T any](b *testing.B) {
+	var total int64
+	for i := 0; i < b.N; i++ {
+		var v T
+		*(*int64)(unsafe.Pointer(&v)) = int64(i)
+		total += byPointer(&v)
+	}
+	keep(total)
+}
+
+func benchPointerEscape[T any](b *testing.B) {
+	var total int64
+	for i := 0; i < b.N; i++ {
+		var v T // moved to heap: &v escapes through byPointerEscape
+		*(*int64)(unsafe.Pointer(&v)) = int64(i)
+		total += byPointerEscape(&v)
+	}
+	keep(total)
+}
+
+// benchSlice views the array through a slice; only arrays have this option
+func benchSlice[T any](b *testing.B) {
+	var total int64
+	var zero T
+	n := int(unsafe.Sizeof(zero) / 8)
+	for i := 0; i < b.N; i++ {
+		var v T
+		s := unsafe.Slice((*int64)(unsafe.Pointer(&v)), n)
+		s[0] = int64(i)
+		total += bySlice(s)
+	}
+	keep(total)
+}
+
+var kept int64
+
+func keep(v int64) { kept += v }
+
+// ----------------------------
+// Result table
+// ----------------------------
+
+type result struct {
+	mode string
+	r    testing.BenchmarkResult
+}
+
+type sizeCase struct {
+	label string
+	runs  []func() result
+}
+
+func run(mode string, fn func(*testing.B)) func() result {
+	return func() result {
+		return result{mode: mode, r: testing.Benchmark(fn)}
+	}
+}
+
+func arrayCase[T any](label string) sizeCase {
+	return sizeCase{label, []func() result{
+		run("value", benchValue[T]),
+		run("pointer", benchPointer[T]),
+		run("slice", benchSlice[T]),
+		run("pointer (escapes)", benchPointerEscape[T]),
+	}}
+}
+
+func structCase[T any](label string) sizeCase {
+	return sizeCase{label, []func() result{
+		run("value", benchValue[T]),
+		run("pointer", benchPointer[T]),
+		run("pointer (escapes)", benchPointerEscape[T]),
+	}}
+}
+
+func report(title string, cases []sizeCase) {
+	fmt.Printf("\n🔹 %s\n", title)
+	fmt.Printf("%-8s %-18s %12s %10s %12s\n", "size", "passing", "ns/op", "allocs/op", "B/op")
+	for _, c := range cases {
+		var valueNs, escapeNs float64
+		for _, runOne := range c.runs {
+			res := runOne()
+			ns := float64(res.r.T.Nanoseconds()) / float64(res.r.N)
+			fmt.Printf("%-8s %-18s %12.2f %10d %12d\n",
+				c.label, res.mode, ns, res.r.AllocsPerOp(), res.r.AllocedBytesPerOp())
+			switch res.mode {
+			case "value":
+				valueNs = ns
+			case "pointer (escapes)":
+				escapeNs = ns
+			}
+		}
+		if escapeNs > valueNs {
+			fmt.Printf("%-8s ➜ escaping pointer is %.1fx SLOWER than copying the value\n\n", "", escapeNs/valueNs)
+		} else {
+			fmt.Printf("%-8s ➜ copying the value is %.1fx slower than an escaping pointer\n\n", "", valueNs/escapeNs)
+		}
+	}
+}
+
+func main() {
+	testing.Init()
+	benchtime := flag.String("benchtime", "100ms", "time spent on each benchmark")
+	flag.Parse()
+	if err := flag.Set("test.benchtime", *benchtime); err != nil {
+		fmt.Fprintln(os.Stderr, "invalid -benchtime:", err)
+		os.Exit(2)
+	}
+
+	report("Arrays: [N]int64 vs *[N]int64 vs []int64", []sizeCase{
+		arrayCase[Arr8]("8B"),
+		arrayCase[Arr64]("64B"),
+		arrayCase[Arr512]("512B"),
+		arrayCase[Arr4K]("4KB"),
+		arrayCase[Arr32K]("32KB"),
+		arrayCase[Arr64K]("64KB"),
+	})
+
+	report("Structs: User vs *User", []sizeCase{
+		structCase[User8]("8B"),
+		structCase[User64]("64B"),
+		structCase[User512]("512B"),
+		structCase[User4K]("4KB"),
+		structCase[User32K]("32KB"),
+		structCase[User64K]("64KB"),
+	})
+}
+
+/*
+----------------------------
+READING THE RESULTS
+----------------------------
+(numbers vary per machine, the SHAPE is what matters)
+
+1) value
+   - ns/op grows with size → the copy is proportional to the bytes.
+   - 0 allocs/op → the copy lives in the callee's stack frame.
+
+2) pointer / slice
+   - Far below "value" → only 8 or 24 bytes are copied.
+   - The remaining cost is building the fresh value itself.
+   - 0 allocs/op → &v does not escape, v stays on main's stack.
+
+3) pointer (escapes)
+   - 1 alloc/op, B/op = size of the value → v was "moved to heap".
+   - This is the SLOWEST option at every size: the allocator must
+     find memory, zero it, and the GC must later sweep it.
+   - For SMALL values (8 B, 64 B) it is several times slower than
+     simply copying a few words by value.
+   - For LARGE values the gap shrinks (the copy itself gets expensive),
+     but the escaping pointer still loses to a plain copy.
+
+----------------------------
+RULE OF THUMB
+----------------------------
+- Small structs/arrays: pass by value, it's cheap and allocation-free.
+- Large structs/arrays: pass a pointer (or a slice) — as long as it
+  does not escape. Non-escaping pointer/slice is the fastest at every size.
+- "Pointer = faster" is only true when the pointer stays on the stack.
+  Check with: go build -gcflags=-m
+*/
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unsafe"
+
+	"go_projects/28.Memory_Map/memmap"
+)
+
+/*
+-------------------------------
+ WHERE DOES EACH VARIABLE REALLY LIVE?
+-------------------------------
+12.Internal_Memory, 13.Closure and 26.Defer draw the memory as:
+
+    | Code Segment | Data Segment | Stack | Heap |
+
+This lesson asks the OPERATING SYSTEM instead of drawing it.
+Linux lists every mapping of a process in /proc/self/maps; the
+memmap package reads it and tells us which mapping holds an address.
+
+Run it (Linux only):
+    go run ./28.Memory_Map
+*/
+
+// Global variables (12.Internal_Memory's `a`) → Data Segment
+var (
+	a      = 10         // initialized global → .data
+	counts [4096]int    // zero-valued global → .bss
+	greet  = "Hello Go" // the string header is a global, its bytes are rodata
+	memory *memmap.Map  // the snapshot we classify against
+)
+
+// Named function → Code Segment (12.Internal_Memory's add, with z on the stack)
+func add(mark *memmap.StackMark, x int, y int) {
+	z := x + y
+	show("local z in add()", memory.Where(mark, unsafe.Pointer(&z)))
+	show("param x in add()", memory.Where(mark, unsafe.Pointer(&x)))
+}
+
+// outer is 13.Closure's outer(): money must outlive outer → moved to heap
+// (noinline: once inlined into main, the compiler could keep money on main's stack)
+//
+//go:noinline
+func outer() (func(), *int) {
+	money := 100
+	show := func() {
+		money = money + a
+	}
+	return show, &money
+}
+
+// defer records from 26.Defer: the deferred closure captures i
+func deferred(mark *memmap.StackMark) {
+	i := 0
+	defer func() {
+		show("i captured by defer", memory.Where(mark, unsafe.Pointer(&i)))
+	}()
+	i++
+}
+
+func show(name string, loc memmap.Location) {
+	fmt.Printf("%-26s %s\n", name, loc)
+}
+
+// snapshot reads the memory map again, or exits if there is none
+func snapshot() *memmap.Map {
+	m, err := memmap.Read()
+	if err != nil {
+		fmt.Println("memmap:", err)
+		os.Exit(1)
+	}
+	return m
+}
+
+func main() {
+	var top memmap.StackMark // first local → anchors the top of main's stack
+
+	memory = snapshot()
+
+	fmt.Println("🔹 Code Segment (function entry points)")
+	show("main.add", memory.WhereFunc(add))
+	show("main.outer", memory.WhereFunc(outer))
+	show("fmt.Println", memory.WhereFunc(fmt.Println))
+
+	fmt.Println("\n🔹 Data Segment (globals)")
+	show("global a", memory.Where(&top, unsafe.Pointer(&a)))
+	show("global counts (zeroed)", memory.Where(&top, unsafe.Pointer(&counts)))
+	show("bytes of \"Hello Go\"", memory.Where(&top, unsafe.Pointer(unsafe.StringData(greet))))
+
+	fmt.Println("\n🔹 Stack (locals)")
+	p := 40
+	show("local p in main()", memory.Where(&top, unsafe.Pointer(&p)))
+	add(&top, 5, 4)
+	deferred(&top)
+
+	fmt.Println("\n🔹 Heap (escaped values)")
+	increment, money := outer()
+	increment()
+	nums := make([]int, 0, 1<<16) // too big for the stack → heap
+	// the allocations may have grown the heap into address space that
+	// was only reserved (---p) when the first snapshot was taken
+	memory = snapshot()
+	show("money captured by closure", memory.Where(&top, unsafe.Pointer(money)))
+	show("closure value (funcval)", memory.Where(&top, *(*unsafe.Pointer)(unsafe.Pointer(&increment))))
+	show("backing array of make()", memory.Where(&top, unsafe.Pointer(unsafe.SliceData(nums))))
+	fmt.Println("(heap (probe) = the heap mapping that holds memmap's probe object;")
+	fmt.Println(" a bigger heap spans more mappings, listed as mmap arena)")
+
+	fmt.Println("\n🔹 Process memory map (/proc/self/maps, classified)")
+	var other uintptr
+	for _, r := range memory.Regions {
+		if r.Kind == memmap.Mmap {
+			if strings.HasPrefix(r.Perms, "rw") { // not just reserved address space
+				other += r.End - r.Start
+			}
+			continue // runtime metadata, vdso, more heap, ...
+		}
+		fmt.Println(r)
+	}
+	fmt.Printf("+ %d KiB of other rw- mappings (mmap arena): runtime metadata,\n", other>>10)
+	fmt.Println("  C libraries, vdso, and any heap outside the probe's mapping")
+}
+
+/*
+-------------------------------
+ WHAT THE OUTPUT SHOWS
+-------------------------------
+(addresses change on every run, the KINDS do not)
+
+1. text      → main.add, main.outer, fmt.Println
+   - mapped from the executable file with r-x (read + execute).
+   - This IS the "Code Segment".
+
+2. rodata    → the bytes of "Hello Go"
+   - mapped from the executable with r-- (read only).
+   - String literals and constant tables live here, next to the code.
+
+3. data/bss  → a, counts
+   - mapped from the executable with rw- (.data has initial values).
+   - counts is all zeros, so it is in .bss, which continues as anonymous
+     memory right after the file mapping (no need to store zeros on disk).
+
+4. goroutine stack → p, z, x, i
+   - NOT the [stack] mapping! That one belongs to the OS thread.
+   - Goroutine stacks are small blocks carved out of the Go arena, which
+     is why we need the StackMark to tell them apart from heap objects.
+   - Note: i is captured by a deferred closure but still on the stack,
+     because the closure does not outlive deferred().
+
+5. heap (probe) → money, the closure value, the make() backing array
+   - Escape analysis moved them to the Go heap arena (a big anonymous
+     rw- mapping). The OS map does not say which anonymous mappings are
+     heap, so memmap only labels the one that holds its probe object. A
+     heap bigger than one mapping shows the rest as "mmap arena".
+     main reads the map again after these allocations: a snapshot
+     taken before them may still show that memory as reserved (---p).
+     Confirm the escapes with:
+         go build -o /dev/null -gcflags=-m ./28.Memory_Map 2>&1 | grep "moved to heap"
+
+Takeaway: the "segments" from the diagrams are real, but Go's Stack
+and Heap both live inside the same runtime-managed arena.
+*/
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package memmap
+
+import "errors"
+
+// Read is only implemented on Linux, where /proc/self/maps exists
+func Read() (*Map, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,225 @@
+// Package memmap maps addresses of a running Go program to the memory
+// segments the lessons talk about (Code, Data, Stack, Heap).
+//
+// It reads the process memory map (/proc/self/maps on Linux) and
+// classifies any address into one of the Kind values below.
+//
+// The runtime does not publish where its heap arenas are, so only the
+// mapping that holds a known heap object (the probe) is ProbeArena. Once
+// the heap grows past that mapping, the rest of it shows up as Mmap.
+package memmap
+
+import (
+	"bufio"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Kind is the memory segment an address belongs to
+type Kind int
+
+const (
+	Unknown        Kind = iota
+	Text                // compiled machine code of the executable (Code Segment)
+	ROData              // read-only data of the executable (string literals, tables)
+	DataBSS             // global variables: initialized (.data) and zeroed (.bss)
+	ProbeArena          // the Go heap mapping that holds the probe object (escaped objects, goroutine stacks)
+	GoroutineStack      // stack of the goroutine that owns the StackMark
+	ThreadStack         // stack of the main OS thread ([stack])
+	Mmap                // other anonymous mappings (more heap, runtime metadata, C libraries, vdso)
+)
+
+var kindNames = [...]string{
+	Unknown:        "unknown",
+	Text:           "text",
+	ROData:         "rodata",
+	DataBSS:        "data/bss",
+	ProbeArena:     "heap (probe)",
+	GoroutineStack: "goroutine stack",
+	ThreadStack:    "thread stack",
+	Mmap:           "mmap arena",
+}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return kindNames[k]
+}
+
+// Region is one line of /proc/self/maps
+type Region struct {
+	Start, End uintptr
+	Perms      string // e.g. "r-xp"
+	Offset     uint64
+	Path       string // file path, "[stack]", "[vdso]" or "" for anonymous memory
+	Kind       Kind
+}
+
+func (r Region) String() string {
+	path := r.Path
+	if path == "" {
+		path = "(anonymous)"
+	}
+	return fmt.Sprintf("%012x-%012x %s %-15s %s", r.Start, r.End, r.Perms, r.Kind, path)
+}
+
+// Contains reports whether addr is inside the region
+func (r Region) Contains(addr uintptr) bool {
+	return addr >= r.Start && addr < r.End
+}
+
+// Map is a snapshot of the process memory map
+type Map struct {
+	Regions []Region
+}
+
+// Location is the answer to "where does this address live?"
+type Location struct {
+	Addr   uintptr
+	Kind   Kind
+	Region *Region // nil for goroutine stack addresses and unmapped addresses
+}
+
+func (l Location) String() string {
+	if l.Region == nil {
+		return fmt.Sprintf("%#x  %s", l.Addr, l.Kind)
+	}
+	return fmt.Sprintf("%#x  %-15s (%x-%x %s)", l.Addr, l.Kind, l.Region.Start, l.Region.End, l.Region.Perms)
+}
+
+// StackMark anchors the top of a goroutine stack.
+//
+// Declare one as the FIRST local of main (or of a goroutine's function)
+// and pass its address down. Every frame called from there lives between
+// the current stack pointer and the mark, so Where can tell stack
+// addresses apart from heap addresses inside the same Go arena.
+type StackMark struct{ _ byte }
+
+// stackAlign is the minimum goroutine stack size; stacks always end on
+// a multiple of it, so rounding the mark up never leaves the stack.
+const stackAlign = 2048
+
+// Where classifies p. mark must point to a StackMark on the CURRENT
+// goroutine's stack; it may be nil if stack addresses are not of interest.
+func (m *Map) Where(mark *StackMark, p unsafe.Pointer) Location {
+	// No calls between reading the stack pointer and the comparison:
+	// a call could grow (and move) the stack under our feet.
+	var here byte
+	lo := uintptr(unsafe.Pointer(&here))
+	hi := (uintptr(unsafe.Pointer(mark)) + stackAlign) &^ (stackAlign - 1)
+	addr := uintptr(p)
+	if mark != nil && addr >= lo && addr < hi {
+		return Location{Addr: addr, Kind: GoroutineStack}
+	}
+	return m.Classify(addr)
+}
+
+// WhereFunc classifies the entry point of the function fn
+func (m *Map) WhereFunc(fn any) Location {
+	return m.Classify(FuncPC(fn))
+}
+
+// FuncPC returns the entry address of the function fn
+func FuncPC(fn any) uintptr {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		panic("memmap: FuncPC of non-func " + v.Type().String())
+	}
+	return v.Pointer()
+}
+
+// Classify finds the region that contains addr. Goroutine stacks are
+// carved out of the Go heap arena, so without a StackMark they show up
+// as ProbeArena (or Mmap, if they are in another heap mapping).
+func (m *Map) Classify(addr uintptr) Location {
+	for i := range m.Regions {
+		if m.Regions[i].Contains(addr) {
+			return Location{Addr: addr, Kind: m.Regions[i].Kind, Region: &m.Regions[i]}
+		}
+	}
+	return Location{Addr: addr, Kind: Unknown}
+}
+
+// Parse reads maps in the /proc/<pid>/maps format and classifies every
+// region. exe is the path of the running executable and heapProbe is the
+// address of any live heap object; both are used to tell regions apart.
+// Only the region that contains heapProbe is ProbeArena: other heap
+// mappings cannot be told apart from other anonymous memory.
+func Parse(text string, exe string, heapProbe uintptr) (*Map, error) {
+	m := &Map{}
+	sc := bufio.NewScanner(strings.NewReader(text))
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" {
+			continue
+		}
+		r, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		m.Regions = append(m.Regions, r)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	var exeEnd uintptr
+	for i := range m.Regions {
+		r := &m.Regions[i]
+		switch {
+		case r.Path == exe && strings.Contains(r.Perms, "x"):
+			r.Kind = Text
+		case r.Path == exe && strings.HasPrefix(r.Perms, "rw"):
+			r.Kind = DataBSS
+			exeEnd = r.End
+		case r.Path == exe:
+			r.Kind = ROData
+		case r.Path == "" && r.Start == exeEnd && exeEnd != 0:
+			// .bss that does not fit in the file mapping continues as
+			// anonymous memory right after the executable
+			r.Kind = DataBSS
+		case r.Path == "[stack]":
+			r.Kind = ThreadStack
+		case r.Contains(heapProbe):
+			r.Kind = ProbeArena
+		default:
+			r.Kind = Mmap
+		}
+	}
+	return m, nil
+}
+
+// parseLine parses "start-end perms offset dev inode [path]"
+func parseLine(line string) (Region, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 5 {
+		return Region{}, fmt.Errorf("memmap: malformed line %q", line)
+	}
+	start, end, ok := strings.Cut(fields[0], "-")
+	if !ok {
+		return Region{}, fmt.Errorf("memmap: malformed range %q", fields[0])
+	}
+	s, err := strconv.ParseUint(start, 16, 64)
+	if err != nil {
+		return Region{}, fmt.Errorf("memmap: %w", err)
+	}
+	e, err := strconv.ParseUint(end, 16, 64)
+	if err != nil {
+		return Region{}, fmt.Errorf("memmap: %w", err)
+	}
+	off, err := strconv.ParseUint(fields[2], 16, 64)
+	if err != nil {
+		return Region{}, fmt.Errorf("memmap: %w", err)
+	}
+
+	r := Region{Start: uintptr(s), End: uintptr(e), Perms: fields[1], Offset: off}
+	if len(fields) > 5 {
+		// paths may contain spaces, and a deleted binary gets " (deleted)"
+		r.Path = strings.TrimSuffix(strings.Join(fields[5:], " "), " (deleted)")
+	}
+	return r, nil
+}
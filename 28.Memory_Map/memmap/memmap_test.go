@@ -0,0 +1,120 @@
+package memmap
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+const exe = "/tmp/go-build/b001/exe/main"
+
+// sample is a trimmed /proc/self/maps of a Go program
+const sample = `
+00400000-004af000 r-xp 00000000 08:01 1234 /tmp/go-build/b001/exe/main
+004af000-0058d000 r--p 000af000 08:01 1234 /tmp/go-build/b001/exe/main
+0058d000-00598000 rw-p 0018d000 08:01 1234 /tmp/go-build/b001/exe/main
+00598000-005d5000 rw-p 00000000 00:00 0
+c000000000-c000400000 rw-p 00000000 00:00 0
+c000400000-c004000000 ---p 00000000 00:00 0
+7f0000000000-7f0000021000 rw-p 00000000 00:00 0
+7ffd00000000-7ffd00021000 rw-p 00000000 00:00 0                          [stack]
+7ffd00100000-7ffd00102000 r-xp 00000000 00:00 0                          [vdso]
+`
+
+const probeAddr = 0xc000010000
+
+func TestParse(t *testing.T) {
+	m, err := Parse(sample, exe, probeAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		start, end  uintptr
+		perms, path string
+		kind        Kind
+	}{
+		{0x400000, 0x4af000, "r-xp", exe, Text},
+		{0x4af000, 0x58d000, "r--p", exe, ROData},
+		{0x58d000, 0x598000, "rw-p", exe, DataBSS},
+		{0x598000, 0x5d5000, "rw-p", "", DataBSS},
+		{0xc000000000, 0xc000400000, "rw-p", "", ProbeArena},
+		{0xc000400000, 0xc004000000, "---p", "", Mmap},
+		{0x7f0000000000, 0x7f0000021000, "rw-p", "", Mmap},
+		{0x7ffd00000000, 0x7ffd00021000, "rw-p", "[stack]", ThreadStack},
+		{0x7ffd00100000, 0x7ffd00102000, "r-xp", "[vdso]", Mmap},
+	}
+	if len(m.Regions) != len(want) {
+		t.Fatalf("%d regions, want %d", len(m.Regions), len(want))
+	}
+	for i, w := range want {
+		r := m.Regions[i]
+		if r.Start != w.start || r.End != w.end || r.Perms != w.perms || r.Path != w.path || r.Kind != w.kind {
+			t.Errorf("region %d = %v, want %x-%x %s %s %q", i, r, w.start, w.end, w.perms, w.kind, w.path)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	m, err := Parse(sample, exe, probeAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		addr uintptr
+		want Kind
+	}{
+		{"function entry", 0x4adc40, Text},
+		{"string literal", 0x4af668, ROData},
+		{"initialized global", 0x58d4c0, DataBSS},
+		{"zeroed global past the file", 0x5bbcc0, DataBSS},
+		{"object next to the probeAddr", 0xc000200000, ProbeArena},
+		{"first byte of the probeAddr's mapping", 0xc000000000, ProbeArena},
+		{"end of the probeAddr's mapping", 0xc000400000, Mmap},
+		{"thread stack", 0x7ffd00010000, ThreadStack},
+		{"unmapped", 0x1000, Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := m.Classify(tt.addr)
+			if loc.Kind != tt.want {
+				t.Errorf("Classify(%#x) = %v, want %v", tt.addr, loc.Kind, tt.want)
+			}
+			if (loc.Region == nil) != (tt.want == Unknown) {
+				t.Errorf("Classify(%#x).Region = %v", tt.addr, loc.Region)
+			}
+		})
+	}
+}
+
+func TestWhereStack(t *testing.T) {
+	var mark StackMark
+	var local int
+	m := &Map{}
+	if got := m.Where(&mark, unsafe.Pointer(&local)).Kind; got != GoroutineStack {
+		t.Errorf("Where(&local) = %v, want goroutine stack", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, line := range []string{
+		"00400000-004af000 r-xp",
+		"00400000 r-xp 00000000 08:01 1234",
+		"zz-004af000 r-xp 00000000 08:01 1234",
+		"00400000-004af000 r-xp nothex 08:01 1234",
+	} {
+		if _, err := Parse(line, exe, probeAddr); err == nil || !strings.HasPrefix(err.Error(), "memmap: ") {
+			t.Errorf("Parse(%q) error = %v, want a memmap error", line, err)
+		}
+	}
+}
+
+func TestParseDeletedPath(t *testing.T) {
+	m, err := Parse("00400000-004af000 r-xp 00000000 08:01 1234 /tmp/my app (deleted)", "/tmp/my app", probeAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := m.Regions[0]; r.Path != "/tmp/my app" || r.Kind != Text {
+		t.Errorf("region = %v, want text of \"/tmp/my app\"", r)
+	}
+}
@@ -0,0 +1,176 @@
+// Package gcstats samples the garbage collector through runtime/metrics
+// and renders the samples as a text chart.
+package gcstats
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"runtime/metrics"
+	"strings"
+	"time"
+)
+
+// Metric names (see `go doc runtime/metrics` for the full list)
+const (
+	heapObjects = "/memory/classes/heap/objects:bytes" // live + not yet swept objects
+	heapLive    = "/gc/heap/live:bytes"                // marked live by the last GC
+	heapGoal    = "/gc/heap/goal:bytes"                // size at which the next GC starts
+	gcCycles    = "/gc/cycles/total:gc-cycles"
+	gcPauses    = "/sched/pauses/total/gc:seconds" // stop-the-world pause histogram
+)
+
+// Sample is one reading of the heap and the GC
+type Sample struct {
+	At          time.Duration // time since the Sampler was created
+	HeapObjects uint64        // bytes occupied by heap objects right now
+	HeapLive    uint64        // bytes the last GC found reachable
+	HeapGoal    uint64        // heap size that triggers the next GC
+	GCCycles    uint64        // completed GC cycles so far
+}
+
+// Sampler reads the same metrics over and over and keeps the history
+type Sampler struct {
+	start   time.Time
+	metrics []metrics.Sample
+	Samples []Sample
+}
+
+// NewSampler starts the clock for Sample.At
+func NewSampler() *Sampler {
+	return &Sampler{
+		start: time.Now(),
+		metrics: []metrics.Sample{
+			{Name: heapObjects},
+			{Name: heapLive},
+			{Name: heapGoal},
+			{Name: gcCycles},
+		},
+	}
+}
+
+// Read takes one sample and appends it to Samples
+func (s *Sampler) Read() Sample {
+	metrics.Read(s.metrics)
+	sample := Sample{
+		At:          time.Since(s.start),
+		HeapObjects: s.metrics[0].Value.Uint64(),
+		HeapLive:    s.metrics[1].Value.Uint64(),
+		HeapGoal:    s.metrics[2].Value.Uint64(),
+		GCCycles:    s.metrics[3].Value.Uint64(),
+	}
+	s.Samples = append(s.Samples, sample)
+	return sample
+}
+
+// Cycles returns how many GC cycles ran between the first and last sample
+func (s *Sampler) Cycles() uint64 {
+	if len(s.Samples) < 2 {
+		return 0
+	}
+	return s.Samples[len(s.Samples)-1].GCCycles - s.Samples[0].GCCycles
+}
+
+// Peak returns the largest HeapObjects value seen
+func (s *Sampler) Peak() uint64 {
+	var peak uint64
+	for _, sample := range s.Samples {
+		peak = max(peak, sample.HeapObjects)
+	}
+	return peak
+}
+
+// PauseSummary describes the GC stop-the-world pauses since program start
+type PauseSummary struct {
+	Count         uint64
+	P50, P99, Max time.Duration // upper bounds of the histogram buckets
+}
+
+func (p PauseSummary) String() string {
+	return fmt.Sprintf("%d pauses, p50 ≤ %v, p99 ≤ %v, max ≤ %v", p.Count, p.P50, p.P99, p.Max)
+}
+
+// Pauses reads the GC pause histogram
+func Pauses() PauseSummary {
+	s := []metrics.Sample{{Name: gcPauses}}
+	metrics.Read(s)
+	h := s[0].Value.Float64Histogram()
+
+	var sum PauseSummary
+	for _, c := range h.Counts {
+		sum.Count += c
+	}
+	if sum.Count == 0 {
+		return sum
+	}
+	sum.P50 = quantile(h, sum.Count, 0.50)
+	sum.P99 = quantile(h, sum.Count, 0.99)
+	sum.Max = quantile(h, sum.Count, 1)
+	return sum
+}
+
+// quantile returns the upper boundary of the bucket holding quantile q
+func quantile(h *metrics.Float64Histogram, total uint64, q float64) time.Duration {
+	want := uint64(math.Ceil(q * float64(total)))
+	var seen uint64
+	for i, c := range h.Counts {
+		seen += c
+		if seen >= want {
+			upper := h.Buckets[i+1]
+			if math.IsInf(upper, 1) {
+				upper = h.Buckets[i]
+			}
+			return time.Duration(upper * float64(time.Second))
+		}
+	}
+	return 0
+}
+
+// Chart draws one row per sample:
+//
+//	time   heap bar (█ = objects, │ = next GC goal)   GC cycles
+//
+// A "◀ GC" marker shows the rows where at least one GC cycle finished.
+func Chart(w io.Writer, samples []Sample, width int) {
+	if len(samples) == 0 {
+		return
+	}
+	var top uint64
+	for _, s := range samples {
+		top = max(top, s.HeapObjects, s.HeapGoal)
+	}
+	scale := float64(width) / float64(top)
+
+	fmt.Fprintf(w, "%8s %9s  %-*s %s\n", "time", "heap", width+1, "█ objects  │ goal", "GCs")
+	prev := samples[0].GCCycles
+	for _, s := range samples {
+		bar := int(float64(s.HeapObjects) * scale)
+		goal := min(int(float64(s.HeapGoal)*scale), width)
+
+		row := []rune(strings.Repeat("█", bar) + strings.Repeat(" ", width+1-bar))
+		if goal >= bar {
+			row[goal] = '│'
+		}
+
+		marker := ""
+		if s.GCCycles > prev {
+			marker = fmt.Sprintf(" ◀ GC x%d", s.GCCycles-prev)
+		}
+		prev = s.GCCycles
+
+		fmt.Fprintf(w, "%8s %9s  %s %d%s\n",
+			s.At.Round(time.Millisecond), Bytes(s.HeapObjects), string(row), s.GCCycles, marker)
+	}
+}
+
+// Bytes formats n as B, KB or MB
+func Bytes(n uint64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%dB", n)
+	}
+}
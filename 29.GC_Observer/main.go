@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"go_projects/29.GC_Observer/gcstats"
+)
+
+/*
+====================================================
+🔹 WATCHING THE GARBAGE COLLECTOR WORK
+====================================================
+
+12.Internal_Memory says:
+    "Garbage Collector (GC) automatically cleans up unused heap memory"
+
+This lab makes that visible. It allocates heap memory on purpose
+(escaping closures and slices) and samples runtime/metrics:
+
+    /memory/classes/heap/objects:bytes → how big the heap is right now
+    /gc/heap/goal:bytes                → when the next GC will start
+    /gc/cycles/total:gc-cycles         → how many GCs finished
+    /sched/pauses/total/gc:seconds     → how long the world was stopped
+
+Run it:
+    go run ./29.GC_Observer
+
+Watch the GC from the runtime's own point of view too:
+    GODEBUG=gctrace=1 go run ./29.GC_Observer
+====================================================
+*/
+
+// Globals → anything stored here stays reachable (escapes to the heap)
+var (
+	retained []func() int // closures we keep alive
+	live     [][]byte     // slices we keep alive
+	garbage  []byte       // last short-lived slice (only one at a time stays reachable)
+)
+
+// escapingClosure returns a closure that captures data.
+// The closure outlives the call → data is moved to the heap (see 13.Closure).
+func escapingClosure(size int) func() int {
+	data := make([]int, size)
+	return func() int {
+		return len(data)
+	}
+}
+
+// churn allocates short-lived slices: garbage right after the next call
+func churn(n, size int) {
+	for i := 0; i < n; i++ {
+		garbage = make([]byte, size)
+	}
+}
+
+// ----------------------------------------------------
+// 1. Heap grows, references are dropped, GC reclaims it
+// ----------------------------------------------------
+func growAndRelease() {
+	fmt.Println("🔹 1. Grow the heap with escaping closures, then drop them")
+
+	s := gcstats.NewSampler()
+	s.Read()
+	for round := 1; round <= 24; round++ {
+		switch {
+		case round <= 12:
+			// keep 16 closures × 64 KB each per round → heap grows
+			for i := 0; i < 16; i++ {
+				retained = append(retained, escapingClosure(8192))
+			}
+		case round == 13:
+			retained = nil // every closure becomes unreachable at once
+			fmt.Println("   (round 13: retained = nil → 12 MB of closures is now garbage)")
+			fallthrough
+		default:
+			churn(16, 64<<10) // keep allocating garbage so the GC has a reason to run
+		}
+		time.Sleep(2 * time.Millisecond)
+		s.Read()
+	}
+
+	gcstats.Chart(os.Stdout, s.Samples, 40)
+	fmt.Printf("   GC cycles during this run: %d\n\n", s.Cycles())
+}
+
+// ----------------------------------------------------
+// 2. GOGC (debug.SetGCPercent) trades memory for CPU
+// ----------------------------------------------------
+
+// workload keeps ~8 MB live and produces ~64 MB of garbage
+func workload() *gcstats.Sampler {
+	live = live[:0]
+	for i := 0; i < 128; i++ {
+		live = append(live, make([]byte, 64<<10))
+	}
+	s := gcstats.NewSampler()
+	s.Read()
+	for i := 0; i < 64; i++ {
+		churn(16, 64<<10)
+		s.Read()
+	}
+	live = nil
+	return s
+}
+
+func compareGCPercent() {
+	fmt.Println("🔹 2. Same workload, different GOGC (debug.SetGCPercent)")
+	fmt.Printf("   %-10s %10s %12s\n", "GOGC", "GC cycles", "peak heap")
+
+	for _, percent := range []int{25, 100, 400} {
+		old := debug.SetGCPercent(percent)
+		runtime.GC() // start every run from a clean heap
+		s := workload()
+		debug.SetGCPercent(old)
+
+		fmt.Printf("   %-10d %10d %12s\n", percent, s.Cycles(), gcstats.Bytes(s.Peak()))
+	}
+	fmt.Println("   ➜ lower GOGC = more GC cycles, smaller heap; higher GOGC = fewer cycles, bigger heap")
+	fmt.Println()
+}
+
+// ----------------------------------------------------
+// 3. GC off + memory limit (debug.SetMemoryLimit)
+// ----------------------------------------------------
+func memoryLimit() {
+	fmt.Println("🔹 3. GOGC=off with a 32 MB memory limit (debug.SetMemoryLimit)")
+
+	oldPercent := debug.SetGCPercent(-1) // no GC triggered by heap growth...
+	oldLimit := debug.SetMemoryLimit(32 << 20)
+	runtime.GC()
+
+	s := workload() // ...only when the total memory approaches the limit
+	gcstats.Chart(os.Stdout, s.Samples[:24], 40)
+
+	debug.SetMemoryLimit(oldLimit)
+	debug.SetGCPercent(oldPercent)
+
+	fmt.Printf("   GC cycles: %d, peak heap: %s\n", s.Cycles(), gcstats.Bytes(s.Peak()))
+	fmt.Println("   ➜ the heap grows freely until it hits the limit, then the GC runs to stay under it")
+	fmt.Println()
+}
+
+// ----------------------------------------------------
+// 4. Finalizers and cleanups: code that runs when the GC frees an object
+// ----------------------------------------------------
+// Both types hold a pointer (the string), so they are not packed into a
+// 16-byte "tiny" block with other objects; tiny blocks may never run
+// their finalizers or cleanups.
+type File struct {
+	name string
+}
+
+type Conn struct {
+	id   int
+	addr string
+}
+
+func finalizersAndCleanups() {
+	fmt.Println("🔹 4. runtime.SetFinalizer vs runtime.AddCleanup")
+
+	events := make(chan string, 2)
+
+	// f and c only live inside this function literal → unreachable once it returns
+	func() {
+		f := &File{name: "notes.txt"}
+		runtime.SetFinalizer(f, func(f *File) {
+			// the finalizer receives the object itself (it is "resurrected" for this call)
+			events <- "finalizer ran for File " + f.name
+		})
+
+		c := &Conn{id: 42, addr: "localhost:8080"}
+		runtime.AddCleanup(c, func(id int) {
+			// the cleanup only receives a copy of what it needs, never the object
+			events <- fmt.Sprintf("cleanup ran for Conn %d", id)
+		}, c.id)
+	}()
+
+	runtime.GC() // objects are unreachable → finalizer/cleanup are queued
+	runtime.GC()
+
+	timeout := time.After(2 * time.Second)
+	for i := 0; i < 2; i++ {
+		select {
+		case e := <-events:
+			fmt.Println("  ", e)
+		case <-timeout:
+			fmt.Println("   (timed out waiting for the GC)")
+			fmt.Println()
+			return
+		}
+	}
+	fmt.Println()
+}
+
+func main() {
+	growAndRelease()
+	compareGCPercent()
+	memoryLimit()
+	finalizersAndCleanups()
+
+	fmt.Println("🔹 GC pauses (stop-the-world) for the whole program")
+	fmt.Println("  ", gcstats.Pauses())
+}
+
+/*
+====================================================
+🔹 WHAT TO NOTICE
+====================================================
+
+1) Chart in section 1
+   - █ bar grows while closures are kept in `retained`.
+   - After `retained = nil` the memory is NOT freed immediately.
+     It is freed at the next GC (◀ GC marker), when the GC finds
+     nothing points to those closures anymore.
+   - │ is the heap goal: GOGC=100 → goal ≈ 2 × live heap.
+
+2) GOGC
+   - GOGC=25  → next GC when the heap grows 25% over the live heap.
+   - GOGC=400 → next GC when it grows 400%.
+   - Same garbage, very different number of GC cycles.
+
+3) Memory limit
+   - GOGC=off alone would let the heap grow forever.
+   - With SetMemoryLimit the GC runs only when needed to stay under it.
+   - Real-world use: containers with a fixed memory budget.
+
+4) Finalizer vs Cleanup
+   - SetFinalizer(obj, fn): fn gets obj back; one per object; objects in
+     a cycle with a finalizer are never freed.
+   - AddCleanup(obj, fn, arg) (Go 1.24+): fn gets only arg; several per
+     object; obj is freed right away. Prefer AddCleanup in new code.
+   - Neither runs at a guaranteed time — never use them for closing
+     files you need closed NOW. Use defer (26.Defer) for that.
+
+5) Pauses
+   - Stop-the-world pauses are usually tens of microseconds: most GC
+     work runs concurrently with your goroutines.
+====================================================
+*/
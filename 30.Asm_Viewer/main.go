@@ -0,0 +1,369 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+====================================================
+🔹 ASSEMBLY VIEWER: SOURCE LINES NEXT TO MACHINE CODE
+====================================================
+
+26.1Defer_Continued tells you to run these by hand:
+    go tool compile -S defer_closure.go > asm.txt
+    go tool objdump -s main.calc deferprog
+
+and then read hundreds of raw lines. This command does it for you:
+   1. builds the lesson (with -gcflags=-d=defer so the compiler tells
+      us how it implemented every defer statement),
+   2. runs `go tool objdump` for ONE function,
+   3. prints every source line followed by the instructions it became,
+   4. highlights defer machinery:
+        runtime.deferproc       → defer record allocated on the HEAP
+        runtime.deferprocStack  → defer record allocated on the STACK
+        runtime.deferreturn     → runs pending defer records at return
+        deferBits set/clear     → OPEN-CODED defer (no record at all)
+   5. optionally dumps the SSA passes as HTML (GOSSAFUNC).
+
+-gcflags adds compiler flags to both builds: -gcflags=-l turns inlining
+off, so a small function that was inlined everywhere gets its own code.
+
+Usage:
+    go run ./30.Asm_Viewer [-func main.calc] [-gcflags flags] [-ssa] [-ssadir dir] <lesson dir>
+
+Examples:
+    go run ./30.Asm_Viewer -func main.calc ./26.1Defer_Continued
+    go run ./30.Asm_Viewer -func main.calculate -ssa ./26.1Defer_Continued
+    go run ./30.Asm_Viewer -func main.a ./26.Defer
+    go run ./30.Asm_Viewer -func main.keep -gcflags=-l ./27.Copy_Cost
+====================================================
+*/
+
+// instr is one line of objdump output
+type instr struct {
+	file string // base name, e.g. main.go (inlined code shows its own file)
+	line int
+	pc   string
+	asm  string
+}
+
+// deferDiag matches the compiler's -d=defer output:
+//
+//	26.1Defer_Continued/main.go:27:2: open-coded defer
+var deferDiag = regexp.MustCompile(`^(.*?):(\d+):\d+: (open-coded|stack-allocated|heap-allocated) defer$`)
+
+// byteStore matches a single-byte store of a constant to the stack. The
+// compiler uses one to mark an open-coded defer as pending
+// (MOVB $0x1, 0x2f(SP)) or done (MOVB $0x0, 0x2f(SP)), but spills look
+// the same: only stores to the deferBits slot count (see deferBitsSlot).
+var byteStore = regexp.MustCompile(`^MOVB \$0x[0-9a-f]+, (0x[0-9a-f]+\(SP\))$`)
+
+// deferBitsSlot returns the stack slot of deferBits ("0x2f(SP)"): the
+// target of the first byte store on an open-coded defer statement's
+// line. It returns "" if there is none.
+func deferBitsSlot(instrs []instr, defers map[string]string) string {
+	for _, in := range instrs {
+		if defers[in.file+":"+strconv.Itoa(in.line)] != "open-coded" {
+			continue
+		}
+		if m := byteStore.FindStringSubmatch(in.asm); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+func main() {
+	fn := flag.String("func", "main.main", "function to disassemble (package.Name)")
+	ssa := flag.Bool("ssa", false, "also write the GOSSAFUNC HTML for the function")
+	ssaDir := flag.String("ssadir", ".", "directory for the GOSSAFUNC HTML")
+	gcflags := flag.String("gcflags", "", "extra compiler flags for the build, e.g. -l to disable inlining")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: asmview [-func main.calc] [-gcflags flags] [-ssa] [-ssadir dir] <lesson dir>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	lesson := flag.Arg(0)
+	if !strings.HasPrefix(lesson, ".") && !filepath.IsAbs(lesson) {
+		lesson = "./" + lesson // a directory, not an import path
+	}
+
+	tmp, err := os.MkdirTemp("", "asmview")
+	if err != nil {
+		fail(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	bin := filepath.Join(tmp, "lesson")
+	defers, err := build(lesson, bin, *gcflags)
+	if err != nil {
+		fail(err)
+	}
+
+	text, err := run("go", "tool", "objdump", "-s", "^"+regexp.QuoteMeta(*fn)+"$", bin)
+	if err != nil {
+		fail(err)
+	}
+	srcPath, instrs := parseObjdump(text)
+	if len(instrs) == 0 {
+		fail(fmt.Errorf("function %s not found in %s (was it inlined or removed? try -gcflags=-l)", *fn, lesson))
+	}
+
+	printInterleaved(*fn, srcPath, instrs, defers)
+
+	if *ssa {
+		if err := dumpSSA(lesson, *fn, *ssaDir, *gcflags); err != nil {
+			fail(err)
+		}
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "asmview:", err)
+	os.Exit(1)
+}
+
+// run executes a command and returns its stdout; stderr is part of the error
+func run(name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s %s: %v\n%s", name, strings.Join(args, " "), err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// build compiles the lesson with the extra gcflags and returns the defer
+// kind reported by the compiler for every "file:line" (file is a base
+// name, like objdump uses)
+func build(lesson, bin, gcflags string) (map[string]string, error) {
+	var out bytes.Buffer
+	cmd := exec.Command("go", "build", "-gcflags="+strings.TrimSpace("-d=defer "+gcflags), "-o", bin, lesson)
+	cmd.Stdout = &out
+	cmd.Stderr = &out // -d=defer diagnostics are written to stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go build %s: %v\n%s", lesson, err, out.String())
+	}
+
+	defers := make(map[string]string)
+	sc := bufio.NewScanner(&out)
+	for sc.Scan() {
+		if m := deferDiag.FindStringSubmatch(sc.Text()); m != nil {
+			defers[filepath.Base(m[1])+":"+m[2]] = m[3]
+		}
+	}
+	return defers, sc.Err()
+}
+
+// parseObjdump returns the source file of the function (from the TEXT
+// header) and its instructions
+//
+//	TEXT main.calc(SB) /root/module/26.1Defer_Continued/main.go
+//	  main.go:7		0x499de0		4c8d6424e0		LEAQ -0x20(SP), R12
+func parseObjdump(text string) (string, []instr) {
+	var srcPath string
+	var instrs []instr
+	for _, line := range strings.Split(text, "\n") {
+		if rest, ok := strings.CutPrefix(line, "TEXT "); ok {
+			if _, path, ok := strings.Cut(rest, " "); ok {
+				srcPath = path
+			}
+			continue
+		}
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		var cols []string
+		for _, f := range fields {
+			if f != "" {
+				cols = append(cols, f)
+			}
+		}
+		if len(cols) < 4 {
+			continue
+		}
+		file, lineStr, ok := strings.Cut(cols[0], ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(lineStr)
+		if err != nil {
+			continue
+		}
+		instrs = append(instrs, instr{file: file, line: n, pc: cols[1], asm: strings.TrimSpace(cols[3])})
+	}
+	return srcPath, instrs
+}
+
+func readLines(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(string(data), "\n")
+}
+
+// annotate explains calls into the runtime's defer machinery
+func annotate(in instr, openCoded bool) string {
+	switch {
+	case strings.Contains(in.asm, "runtime.deferprocStack("):
+		return "◀ defer record on the STACK"
+	case strings.Contains(in.asm, "runtime.deferproc("):
+		return "◀ defer record on the HEAP"
+	case strings.Contains(in.asm, "runtime.deferreturn(") && openCoded:
+		return "◀ runs pending defers (only reached after a recovered panic)"
+	case strings.Contains(in.asm, "runtime.deferreturn("):
+		return "◀ runs the defer records at return (LIFO)"
+	case strings.Contains(in.asm, "runtime.deferprocat(") || strings.Contains(in.asm, "runtime.deferrangefunc("):
+		return "◀ defer inside a range-over-func loop"
+	}
+	return ""
+}
+
+// isDeferBitsStore reports whether asm is a byte store to slot
+func isDeferBitsStore(asm, slot string) bool {
+	m := byteStore.FindStringSubmatch(asm)
+	return m != nil && m[1] == slot
+}
+
+func printInterleaved(fn, srcPath string, instrs []instr, defers map[string]string) {
+	srcBase := filepath.Base(srcPath)
+	src := readLines(srcPath)
+
+	openCoded := false
+	for _, in := range instrs {
+		if defers[in.file+":"+strconv.Itoa(in.line)] == "open-coded" {
+			openCoded = true
+		}
+	}
+	slot := deferBitsSlot(instrs, defers)
+
+	fmt.Printf("🔹 %s  (%s)\n", fn, srcPath)
+	counts := map[string]int{}
+	seen := map[string]bool{}
+	last := ""
+	pending := false // a deferBits bit was set by a defer statement
+	calling := false // a bit was just cleared: the next CALL is the deferred call
+	for _, in := range instrs {
+		key := in.file + ":" + strconv.Itoa(in.line)
+		if key != last {
+			last = key
+			fmt.Println()
+			switch {
+			case in.file == srcBase && in.line-1 < len(src):
+				fmt.Printf("%4d │ %s\n", in.line, strings.TrimSpace(src[in.line-1]))
+			default:
+				fmt.Printf("     │ (inlined from %s)\n", key)
+			}
+			if kind, ok := defers[key]; ok {
+				fmt.Printf("     │ ⚑ compiler: %s defer\n", kind)
+				if !seen[key] {
+					counts[kind+" defer"]++
+				}
+			}
+			seen[key] = true
+		}
+
+		// Open-coded defers keep one bit per defer statement in a byte
+		// on the stack ("deferBits"): set at the defer statement, cleared
+		// at return right before the deferred function is called.
+		note := annotate(in, openCoded)
+		switch {
+		case slot != "" && isDeferBitsStore(in.asm, slot):
+			switch {
+			case defers[key] == "open-coded":
+				note = "◀ open-coded: set deferBits (defer is now pending)"
+				pending = true
+			case !pending:
+				note = "◀ open-coded: deferBits = 0 (nothing pending yet)"
+			default:
+				note = "◀ open-coded: clear one bit, that defer runs next"
+				calling = true
+			}
+		case calling && strings.HasPrefix(in.asm, "CALL "):
+			note = "◀ open-coded: deferred call made directly at return"
+			calling = false
+		}
+
+		for _, name := range []string{"runtime.deferproc(", "runtime.deferprocStack(", "runtime.deferreturn("} {
+			if strings.Contains(in.asm, name) {
+				counts[strings.TrimSuffix(name, "(")]++
+			}
+		}
+		fmt.Printf("     │   %s  %-40s %s\n", in.pc, in.asm, note)
+	}
+
+	fmt.Println("\n🔹 Summary")
+	if len(counts) == 0 {
+		fmt.Println("   no defer machinery in this function")
+	}
+	for _, name := range []string{"open-coded defer", "stack-allocated defer", "heap-allocated defer",
+		"runtime.deferproc", "runtime.deferprocStack", "runtime.deferreturn"} {
+		if counts[name] > 0 {
+			fmt.Printf("   %-24s %d\n", name, counts[name])
+		}
+	}
+}
+
+// dumpSSA rebuilds the lesson with GOSSAFUNC set; the compiler writes
+// one HTML page with every SSA pass of the function
+func dumpSSA(lesson, fn, dir, gcflags string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("go", "build", "-gcflags="+gcflags, "-o", os.DevNull, lesson)
+	cmd.Env = append(os.Environ(), "GOSSAFUNC="+fn, "GOSSADIR="+abs)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("GOSSAFUNC build: %v\n%s", err, out)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "dumped SSA") {
+			fmt.Println("\n🔹 SSA:", line)
+		}
+	}
+	return nil
+}
+
+/*
+====================================================
+🔹 WHAT TO LOOK FOR (26.Defer / 26.1Defer_Continued)
+====================================================
+
+26.Defer says: "It stores a 'defer record' — function pointer + arguments
+— in a small list attached to the current goroutine's stack."
+
+Run the viewer on main.calc and you will see that, for simple functions,
+that is NOT what the compiler does since Go 1.14:
+
+   defer show()   → ⚑ compiler: open-coded defer
+                    MOVB $0x1, 0x2f(SP)   ◀ set deferBits (defer is now pending)
+   return result  → MOVB $0x0, 0x2f(SP)   ◀ clear one bit, that defer runs next
+                    CALL CX               ◀ deferred call made directly at return
+                    RET
+                    CALL runtime.deferreturn(SB)
+
+- OPEN-CODED: the compiler inlines the deferred call at every return.
+  A bit in "deferBits" (one byte on the stack) remembers which defers
+  were reached. No defer record, no runtime call on the normal path.
+- runtime.deferreturn after RET is only used when a panic is recovered
+  and the function has to finish its pending defers.
+- A defer record (runtime.deferprocStack / runtime.deferproc) is still
+  used when open-coding is impossible: defer inside a loop, more than
+  8 defers, or too many returns. See 31.Defer_Cost for the numbers.
+====================================================
+*/
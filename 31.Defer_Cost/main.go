@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+/*
+====================================================
+🔹 HOW MUCH DOES A DEFER COST?
+====================================================
+
+26.Defer Q6 says:
+    "Each defer adds runtime bookkeeping. Avoid defers in *hot loops*"
+
+Since Go 1.14 that depends on HOW the compiler implements the defer:
+
+   1. open-coded        → the deferred call is pasted at every return,
+                          a bit in "deferBits" remembers it is pending.
+                          Almost free.
+   2. stack-allocated   → a defer record is built on the stack and linked
+                          into the goroutine's defer list (runtime.deferprocStack).
+   3. heap-allocated    → a defer record comes from the runtime's pool / heap
+                          (runtime.deferproc). Used for defers inside loops.
+
+Open-coding is only possible when:
+   - the defer is NOT inside a loop,
+   - the function has at most 8 defers,
+   - (number of returns × number of defers) stays small.
+
+This lab benchmarks one case of each and asks the compiler
+(go build -gcflags=-d=defer) which implementation it chose.
+
+Run it:
+    go run ./31.Defer_Cost
+    go run ./31.Defer_Cost -benchtime=500ms
+
+Check the assembly of any case:
+    go run ./30.Asm_Viewer -func main.deferInLoop ./31.Defer_Cost
+====================================================
+*/
+
+// open counts acquired-but-not-released resources (must end at 0)
+var open int
+
+func acquire() { open++ }
+func release() { open-- }
+
+// ----------------------------
+// The cases
+// ----------------------------
+
+// noDefer releases by hand (easy to forget on an early return!)
+//
+//go:noinline
+func noDefer() {
+	acquire()
+	release()
+}
+
+// singleDefer is the classic "acquire, defer release" → open-coded
+func singleDefer() {
+	acquire()
+	defer release()
+}
+
+// deferClosure captures a local in a deferred closure → still open-coded
+func deferClosure() {
+	n := 1
+	acquire()
+	defer func() {
+		open -= n
+	}()
+}
+
+// deferInLoop runs the defer statement inside a loop → the compiler
+// cannot know how many records it needs → heap-allocated record
+func deferInLoop() {
+	for i := 0; i < 1; i++ {
+		acquire()
+		defer release()
+	}
+}
+
+// nineDefers has more than 8 defers → too many bits for open-coding
+// → every defer becomes a stack-allocated record
+func nineDefers() {
+	acquire()
+	defer release()
+	acquire()
+	defer release()
+	acquire()
+	defer release()
+	acquire()
+	defer release()
+	acquire()
+	defer release()
+	acquire()
+	defer release()
+	acquire()
+	defer release()
+	acquire()
+	defer release()
+	acquire()
+	defer release()
+}
+
+// nineManual is nineDefers without defer, to compare per-release cost
+//
+//go:noinline
+func nineManual() {
+	for i := 0; i < 9; i++ {
+		acquire()
+	}
+	for i := 0; i < 9; i++ {
+		release()
+	}
+}
+
+type benchCase struct {
+	name string
+	fn   func()
+}
+
+var cases = []benchCase{
+	{"noDefer", noDefer},
+	{"singleDefer", singleDefer},
+	{"deferClosure", deferClosure},
+	{"deferInLoop", deferInLoop},
+	{"nineManual", nineManual},
+	{"nineDefers", nineDefers},
+}
+
+// ----------------------------
+// Ask the compiler what it did
+// ----------------------------
+
+var deferDiag = regexp.MustCompile(`:(\d+):\d+: (open-coded|stack-allocated|heap-allocated) defer$`)
+
+// source is this file. The compiler diagnostics give line numbers, so the
+// lesson needs its own source; embedding it works for a built binary too.
+//
+//go:embed main.go
+var source string
+
+// compilerDecisions rebuilds this lesson with -d=defer and returns, per
+// function name, the kind of every defer statement in it
+func compilerDecisions() (map[string][]string, error) {
+	// the file only imports the standard library: a copy in a temporary
+	// module builds anywhere
+	dir, err := os.MkdirTemp("", "defercost")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module defercost\n\ngo 1.25\n"), 0o644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(source), 0o644); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command("go", "build", "-gcflags=-d=defer", "-o", os.DevNull, ".")
+	cmd.Dir = dir
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go build: %v\n%s", err, out.String())
+	}
+
+	kindByLine := map[int]string{}
+	sc := bufio.NewScanner(&out)
+	for sc.Scan() {
+		if m := deferDiag.FindStringSubmatch(sc.Text()); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			kindByLine[line] = m[2]
+		}
+	}
+
+	// map line numbers back to the function that contains them
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "main.go", source, 0)
+	if err != nil {
+		return nil, err
+	}
+	kinds := map[string][]string{}
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		from, to := fset.Position(fd.Pos()).Line, fset.Position(fd.End()).Line
+		for line := from; line <= to; line++ {
+			if kind, ok := kindByLine[line]; ok {
+				kinds[fd.Name.Name] = append(kinds[fd.Name.Name], kind)
+			}
+		}
+	}
+	return kinds, nil
+}
+
+func describe(kinds []string) string {
+	if len(kinds) == 0 {
+		return "no defer"
+	}
+	count := map[string]int{}
+	for _, k := range kinds {
+		count[k]++
+	}
+	out := ""
+	for _, k := range []string{"open-coded", "stack-allocated", "heap-allocated"} {
+		if count[k] > 0 {
+			if out != "" {
+				out += ", "
+			}
+			out += fmt.Sprintf("%d× %s", count[k], k)
+		}
+	}
+	return out
+}
+
+func main() {
+	testing.Init()
+	benchtime := flag.String("benchtime", "200ms", "time spent on each benchmark")
+	flag.Parse()
+	if err := flag.Set("test.benchtime", *benchtime); err != nil {
+		fmt.Fprintln(os.Stderr, "invalid -benchtime:", err)
+		os.Exit(2)
+	}
+
+	kinds, err := compilerDecisions()
+	if err != nil {
+		fmt.Println("⚠ could not ask the compiler (is the go command available?):", err)
+	}
+
+	fmt.Printf("%-14s %10s %10s %10s   %s\n", "case", "ns/op", "allocs/op", "B/op", "compiler (-d=defer)")
+	for _, c := range cases {
+		r := testing.Benchmark(func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.fn()
+			}
+		})
+		ns := float64(r.T.Nanoseconds()) / float64(r.N)
+		fmt.Printf("%-14s %10.2f %10d %10d   %s\n", c.name, ns, r.AllocsPerOp(), r.AllocedBytesPerOp(), describe(kinds[c.name]))
+	}
+
+	if open != 0 {
+		fmt.Println("⚠ resources leaked:", open)
+	}
+}
+
+/*
+====================================================
+🔹 READING THE RESULTS
+====================================================
+(numbers vary per machine, the ORDER does not)
+
+noDefer → singleDefer ≈ deferClosure
+   - Open-coded defers cost only a few ns more than calling release()
+     by hand (the deferBits store, and a function with defer is never inlined).
+   - The closure does not escape, so it lives on the stack: 0 allocs.
+   - Q6's "avoid defer" advice is outdated for this common case.
+
+deferInLoop
+   - heap-allocated: runtime.deferproc + runtime.deferreturn on every call.
+   - Several times slower than open-coded. Records come from a per-P pool,
+     so allocs/op usually stays 0 — the cost is the runtime bookkeeping.
+
+nineDefers vs nineManual
+   - 9 defers > 8 → no open-coding → 9 stack-allocated records.
+   - Each record is linked into the goroutine's defer list and walked
+     by runtime.deferreturn at return.
+
+Rule of thumb:
+   - Use defer freely for cleanup in normal functions.
+   - In a hot loop, move the body into its own function so the defer
+     is open-coded there, instead of deferring inside the loop
+     (which also delays every release until the outer function returns!).
+====================================================
+*/
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"go_projects/32.Named_Returns_Recover/returns"
+)
+
+/*
+====================================================
+🔹 NAMED RETURNS + DEFER + RECOVER IN REAL CODE
+====================================================
+
+26.1Defer_Continued showed:
+    calc()      (unnamed result) → 5   the defer changed a LOCAL copy
+    calculate() (named result)   → 15  the defer changed the RESULT slot
+
+That difference is why real Go code uses named results with defer:
+   1. turning a panic into an error   → defer + recover + `err = ...`
+   2. wrapping every returned error   → defer + `err = fmt.Errorf(...%w)`
+   3. not losing Close() errors       → defer + `err = errors.Join(err, c.Close())`
+
+Each section prints what the functions in returns/ actually return.
+The expected results are table tests in returns/returns_test.go.
+
+Run it:
+    go run ./32.Named_Returns_Recover
+    go test ./32.Named_Returns_Recover/...
+====================================================
+*/
+
+// ----------------------------
+// 1. named/unnamed × panic/no panic × early/normal return
+// ----------------------------
+func recoverMatrix() {
+	fmt.Println("🔹 1. Recover: named vs unnamed results")
+
+	for _, named := range []bool{false, true} {
+		fn, kind := returns.Unnamed, "unnamed"
+		if named {
+			fn, kind = returns.Named, "named"
+		}
+		for _, panics := range []bool{false, true} {
+			for _, early := range []bool{false, true} {
+				n, err := fn(panics, early)
+				fmt.Printf("  %-7s panic=%-5v early=%-5v → n=%d err=%v\n", kind, panics, early, n, err)
+			}
+		}
+	}
+	// unnamed + panic + no early return → n=0 err=<nil>: the panic is
+	// swallowed and the caller sees zero values. named turns it into an error.
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. defer-based error wrapping
+// ----------------------------
+func wrapping() {
+	fmt.Println("🔹 2. One deferred wrap adds context to every error")
+
+	tests := []struct {
+		name          string
+		exists, valid bool
+	}{
+		{"app.yaml exists and valid", true, true},
+		{"app.yaml missing", false, false},
+		{"app.yaml invalid", true, false},
+	}
+
+	for _, tt := range tests {
+		err := returns.ReadConfig("app.yaml", tt.exists, tt.valid)
+		fmt.Printf("  %-28s err=%v (is ErrNotFound: %v)\n", tt.name, err, errors.Is(err, returns.ErrNotFound))
+	}
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. errors.Join on Close errors
+// ----------------------------
+func closeErrors() {
+	fmt.Println("🔹 3. errors.Join keeps both the work error and the Close error")
+
+	errWork := errors.New("write failed")
+	errClose := errors.New("close failed")
+
+	tests := []struct {
+		name     string
+		workErr  error
+		closeErr error
+	}{
+		{name: "work ok, close ok"},
+		{name: "work fails, close ok", workErr: errWork},
+		{name: "work ok, close fails", closeErr: errClose},
+		{name: "work fails, close fails", workErr: errWork, closeErr: errClose},
+	}
+
+	for _, tt := range tests {
+		r := &returns.Resource{Name: "data.txt", CloseErr: tt.closeErr}
+		err := returns.Process(r, func() error { return tt.workErr })
+		fmt.Printf("  %-26s err=%q closed=%v\n", tt.name, fmt.Sprint(err), r.Closed)
+	}
+	fmt.Println()
+}
+
+func main() {
+	recoverMatrix()
+	wrapping()
+	closeErrors()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) Unnamed results + recover = SILENT FAILURE
+   - After a recovered panic the function returns the ZERO values.
+   - `err = ...` inside the deferred closure edits a local, not the result.
+   - The caller gets (0, nil) and thinks everything worked.
+
+2) Named results are the only way a defer can change what is returned.
+   Order on `return x, nil`:
+       1. x and nil are assigned to the named slots (n, err)
+       2. deferred functions run (they may modify n and err)
+       3. the function returns the CURRENT n and err
+
+3) Wrapping in one place:
+       defer func() {
+           if err != nil {
+               err = fmt.Errorf("read config %q: %w", name, err)
+           }
+       }()
+   %w keeps the original error → errors.Is(err, ErrNotFound) still works.
+
+4) Never drop Close errors:
+       defer f.Close()                                  // ❌ error ignored
+       defer func() { err = errors.Join(err, f.Close()) }() // ✅
+   For files you WRITE, a failed Close can mean the data never hit the disk.
+====================================================
+*/
@@ -0,0 +1,114 @@
+// Package returns shows what a deferred function can and cannot change
+// about a function's results (see 26.1Defer_Continued: calc vs calculate).
+package returns
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotFound is returned by ReadConfig when the config does not exist
+var ErrNotFound = errors.New("not found")
+
+// ----------------------------
+// 1. Recover: unnamed vs named results
+// ----------------------------
+
+// Unnamed mirrors calc(): its deferred closure adds 10 to n and recovers
+// panics, but it can only touch the LOCAL variables. The result values
+// are copied out by the return statement (or are zero after a panic),
+// so neither the +10 nor the recovered panic reach the caller.
+func Unnamed(shouldPanic, early bool) (int, error) {
+	n := 0
+	var err error
+	defer func() {
+		n += 10
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered: %v", r) // lost: err is not the result slot
+		}
+	}()
+
+	n = 5
+	if early {
+		return n, err
+	}
+	if shouldPanic {
+		panic("boom")
+	}
+	n = 7
+	return n, err
+}
+
+// Named mirrors calculate(): n and err ARE the result slots, so the
+// deferred closure can change them after the return statement ran —
+// and after a panic it can turn the panic into an error.
+func Named(shouldPanic, early bool) (n int, err error) {
+	defer func() {
+		n += 10
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered: %v", r)
+		}
+	}()
+
+	n = 5
+	if early {
+		return n, nil
+	}
+	if shouldPanic {
+		panic("boom")
+	}
+	return 7, nil
+}
+
+// ----------------------------
+// 2. Defer-based error wrapping
+// ----------------------------
+
+// ReadConfig adds context to EVERY error it returns with one deferred
+// wrap, instead of repeating fmt.Errorf at each return statement.
+func ReadConfig(name string, exists, valid bool) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("read config %q: %w", name, err)
+		}
+	}()
+
+	if !exists {
+		return ErrNotFound
+	}
+	if !valid {
+		return errors.New("invalid syntax")
+	}
+	return nil
+}
+
+// ----------------------------
+// 3. errors.Join with io.Closer
+// ----------------------------
+
+// Resource is a fake file/connection whose Close can fail
+type Resource struct {
+	Name     string
+	CloseErr error
+	Closed   bool
+}
+
+// Close implements io.Closer
+func (r *Resource) Close() error {
+	r.Closed = true
+	return r.CloseErr
+}
+
+var _ io.Closer = (*Resource)(nil)
+
+// Process works with c and always closes it. A Close error is NOT
+// silently dropped (the classic `defer f.Close()` bug): it is joined
+// with the work error so the caller sees both.
+func Process(c io.Closer, work func() error) (err error) {
+	defer func() {
+		err = errors.Join(err, c.Close())
+	}()
+
+	return work()
+}
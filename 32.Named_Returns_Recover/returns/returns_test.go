@@ -0,0 +1,100 @@
+package returns
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		named, panics, early bool
+		wantN                int
+		wantErr              bool
+	}{
+		// unnamed: the defer runs, but the caller never sees its changes
+		{named: false, panics: false, early: false, wantN: 7},
+		{named: false, panics: false, early: true, wantN: 5},
+		{named: false, panics: true, early: false, wantN: 0}, // panic swallowed, results are zero values!
+		{named: false, panics: true, early: true, wantN: 5},  // early return happens before the panic
+
+		// named: the defer edits the result slots after `return`
+		{named: true, panics: false, early: false, wantN: 17},
+		{named: true, panics: false, early: true, wantN: 15},
+		{named: true, panics: true, early: false, wantN: 15, wantErr: true}, // panic → error
+		{named: true, panics: true, early: true, wantN: 15},
+	}
+
+	for _, tt := range tests {
+		fn, kind := Unnamed, "unnamed"
+		if tt.named {
+			fn, kind = Named, "named"
+		}
+		t.Run(fmt.Sprintf("%s/panic=%v/early=%v", kind, tt.panics, tt.early), func(t *testing.T) {
+			n, err := fn(tt.panics, tt.early)
+			if n != tt.wantN || (err != nil) != tt.wantErr {
+				t.Errorf("got n=%d err=%v, want n=%d err!=nil %v", n, err, tt.wantN, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		exists, valid bool
+		wantNotFound  bool
+		wantErr       bool
+	}{
+		{name: "exists and valid", exists: true, valid: true},
+		{name: "missing", exists: false, wantNotFound: true, wantErr: true},
+		{name: "invalid", exists: true, valid: false, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ReadConfig("app.yaml", tt.exists, tt.valid)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("errors.Is(%v, ErrNotFound) = %v, want %v", err, !tt.wantNotFound, tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	errWork := errors.New("write failed")
+	errClose := errors.New("close failed")
+
+	tests := []struct {
+		name      string
+		workErr   error
+		closeErr  error
+		wantWork  bool
+		wantClose bool
+	}{
+		{name: "work ok, close ok"},
+		{name: "work fails, close ok", workErr: errWork, wantWork: true},
+		{name: "work ok, close fails", closeErr: errClose, wantClose: true},
+		{name: "work fails, close fails", workErr: errWork, closeErr: errClose, wantWork: true, wantClose: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{Name: "data.txt", CloseErr: tt.closeErr}
+			err := Process(r, func() error { return tt.workErr })
+
+			if !r.Closed {
+				t.Error("resource not closed")
+			}
+			if errors.Is(err, errWork) != tt.wantWork {
+				t.Errorf("err = %q: work error kept = %v, want %v", err, !tt.wantWork, tt.wantWork)
+			}
+			if errors.Is(err, errClose) != tt.wantClose {
+				t.Errorf("err = %q: close error kept = %v, want %v", err, !tt.wantClose, tt.wantClose)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"go_projects/33.Safe_Go/safego"
+)
+
+/*
+====================================================
+🔹 SAFE GOROUTINES: RECOVER THAT ACTUALLY WORKS
+====================================================
+
+26.Defer ends with exampleRecoverDefer():
+    defer func() {
+        if r := recover(); r != nil {
+            fmt.Println("Recovered from:", r)
+        }
+    }()
+
+That only protects the goroutine that deferred it. A panic in a CHILD
+goroutine is not caught by the parent's recover — it kills the whole
+program, every other goroutine included.
+
+The safego package makes the pattern reusable:
+   safego.Do(fn) error     → panic becomes a *PanicError (value + stack)
+   safego.Go(fn)           → goroutine with its own deferred recover
+   safego.Handler(h)       → HTTP handler: panic → 500, server keeps going
+   OnPanic(hook)           → every recovered panic is reported
+
+Each section prints what happens; the expected results are tests in
+safego/safego_test.go.
+
+Run it:
+    go run ./33.Safe_Go
+    go test ./33.Safe_Go/...
+====================================================
+*/
+
+// crashEnv makes this binary run the crashing demo instead of the lesson
+const crashEnv = "SAFEGO_CRASH"
+
+// ----------------------------
+// 1. The parent's recover does NOT cover a child goroutine
+// ----------------------------
+
+// unprotectedChild is exampleRecoverDefer with the panic moved into a
+// child goroutine. It runs in a subprocess because it crashes.
+func unprotectedChild() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("Recovered from:", r) // never printed
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		panic("🔥 panic in child goroutine")
+	}()
+	<-done
+	time.Sleep(100 * time.Millisecond) // the runtime is already exiting
+}
+
+func parentCannotRecover() {
+	fmt.Println("🔹 1. A parent's recover cannot catch a child's panic")
+
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Println("  cannot locate own binary:", err)
+		return
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command(exe)
+	cmd.Env = append(os.Environ(), crashEnv+"=1")
+	cmd.Stdout = &stderr
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+
+	exitCode := 0
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	}
+	first, _, _ := strings.Cut(stderr.String(), "\n")
+	fmt.Printf("  unprotected child → exit status %d: %q\n", exitCode, first)
+	fmt.Printf("  parent's deferred recover ran: %v\n", strings.Contains(stderr.String(), "Recovered from"))
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. safego.Go: the child recovers itself and reports
+// ----------------------------
+func protectedChildren() {
+	fmt.Println("🔹 2. safego.Go recovers inside the child and reports")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	r := safego.New(func(pe *safego.PanicError) {
+		mu.Lock()
+		fmt.Printf("  hook: %v (%d bytes of stack)\n", pe, len(pe.Stack))
+		mu.Unlock()
+		// the hook runs after the worker's own deferred calls: a deferred
+		// wg.Done() would let main go on before the panic is reported
+		wg.Done()
+	})
+
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		r.Go(func() {
+			if i%2 == 1 {
+				panic(fmt.Sprintf("worker %d failed", i))
+			}
+			results[i] = i * i
+			wg.Done()
+		})
+	}
+	wg.Wait()
+	fmt.Println("  all 5 workers finished, program alive:", results)
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. safego.Do: panic → typed error
+// ----------------------------
+func panicToError() {
+	fmt.Println("🔹 3. safego.Do turns a panic into *PanicError")
+
+	// no hook yet: Default prints the panic and its stack to stderr
+	err := safego.Do(func() { panic("nobody is listening") })
+	fmt.Println("  without a hook (stack above, on stderr):", err)
+
+	safego.OnPanic(func(*safego.PanicError) {}) // the errors below are enough
+
+	var nilMap map[string]int
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"no panic", func() {}},
+		{"panic(string)", func() { panic("boom") }},
+		{"panic(io.EOF)", func() { panic(io.EOF) }},
+		{"runtime error: nil map write", func() { nilMap["a"] = 1 }},
+		{"panic(nil) is a real panic since Go 1.21", func() { panic(nil) }},
+	}
+	for _, tt := range tests {
+		err := safego.Do(tt.fn)
+		var pe *safego.PanicError
+		fmt.Printf("  %-42s err=%v (*PanicError: %v, is io.EOF: %v)\n",
+			tt.name, err, errors.As(err, &pe), errors.Is(err, io.EOF))
+	}
+	fmt.Println()
+}
+
+// ----------------------------
+// 4. safego.Handler: one bad request is not a dead server
+// ----------------------------
+func httpHandler() {
+	fmt.Println("🔹 4. safego.Handler answers 500 and keeps serving")
+
+	reports := 0
+	r := safego.New(func(pe *safego.PanicError) { reports++ })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "fine")
+	})
+	mux.HandleFunc("/panic", func(http.ResponseWriter, *http.Request) {
+		panic("handler bug")
+	})
+	mux.HandleFunc("/late-panic", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "half a body") // sends 200 and the headers
+		panic("handler bug after writing")
+	})
+	h := r.Handler(mux)
+
+	for _, path := range []string{"/ok", "/panic", "/ok", "/late-panic"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		fmt.Printf("  GET %-12s status=%d body=%q reports=%d\n", path, rec.Code, rec.Body.String(), reports)
+	}
+	// /late-panic: the 200 is already on the wire, so no 500 is added to
+	// the body; the panic is still reported
+
+	// http.ErrAbortHandler must reach net/http untouched
+	abort := r.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	err := safego.Do(func() {
+		abort.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	fmt.Printf("  panic(http.ErrAbortHandler) passes through: err=%v\n", err)
+	fmt.Println()
+}
+
+func main() {
+	if os.Getenv(crashEnv) != "" {
+		unprotectedChild()
+		return
+	}
+
+	parentCannotRecover()
+	protectedChildren()
+	panicToError()
+	httpHandler()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) recover only works:
+   - inside a DEFERRED function,
+   - in the SAME goroutine that panicked.
+   A panic that reaches the top of any goroutine's stack ends the program
+   (exit status 2), no matter what the other goroutines deferred.
+
+2) So every `go` statement that may panic needs its own recover:
+       go func() {
+           defer func() { recover() ... }()
+           work()
+       }()
+   safego.Go is exactly that, plus reporting.
+
+3) Don't throw the panic away — keep it as an error:
+   - Value: what was passed to panic() (runtime errors are runtime.Error)
+   - Stack: debug.Stack() taken inside the deferred function still shows
+     the frames of the panicking code (the stack is not unwound yet).
+   - Unwrap(): panic(io.EOF) → errors.Is(err, io.EOF) is true.
+
+4) HTTP:
+   - net/http already recovers per connection, but it only logs and
+     drops the connection. The wrapper sends a proper 500 and reports.
+   - Only if nothing was written yet: once headers are sent, a 500
+     cannot replace them. The wrapper tracks Write/WriteHeader for that.
+   - http.ErrAbortHandler is the one panic to let through: it is how a
+     handler aborts a response on purpose.
+
+5) Recovering is not fixing: report it, and treat the state the
+   panicking code touched as suspect. A recover with nobody listening
+   is worse than a crash, so safego prints to stderr until a hook is set.
+====================================================
+*/
@@ -0,0 +1,174 @@
+// Package safego recovers panics in goroutines, plain function calls and
+// HTTP handlers, and turns them into *PanicError values that are handed
+// to reporting hooks (26.Defer's exampleRecoverDefer, made reusable).
+package safego
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError is a recovered panic: the value passed to panic() and the
+// stack of the goroutine at the moment it panicked.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap exposes the panic value when it is an error, so
+// errors.Is(err, io.EOF) works after panic(io.EOF).
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Hook is called with every recovered panic
+type Hook func(*PanicError)
+
+// Recoverer runs functions with a deferred recover and reports every
+// recovered panic to its hooks. The zero value is ready to use.
+//
+// A Recoverer without hooks prints the panic and its stack to stderr: a
+// recovered panic must never disappear without a trace.
+type Recoverer struct {
+	mu    sync.RWMutex
+	hooks []Hook
+}
+
+// New returns a Recoverer that reports to hooks
+func New(hooks ...Hook) *Recoverer {
+	return &Recoverer{hooks: hooks}
+}
+
+// OnPanic adds a reporting hook; safe to call while goroutines are running
+func (r *Recoverer) OnPanic(hook Hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, hook)
+}
+
+// stderr is where panics go when no hook is registered
+var stderr io.Writer = os.Stderr
+
+func (r *Recoverer) report(pe *PanicError) {
+	r.mu.RLock()
+	hooks := r.hooks
+	r.mu.RUnlock()
+	if len(hooks) == 0 {
+		fmt.Fprintf(stderr, "safego: recovered %v\n%s", pe, pe.Stack)
+		return
+	}
+	for _, hook := range hooks {
+		hook(pe)
+	}
+}
+
+// capture must be called directly by a deferred function:
+// recover only works in the function that was deferred.
+func (r *Recoverer) capture(v any) *PanicError {
+	pe := &PanicError{Value: v, Stack: debug.Stack()}
+	r.report(pe)
+	return pe
+}
+
+// Do calls fn and converts a panic into a *PanicError
+func (r *Recoverer) Do(fn func()) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = r.capture(v)
+		}
+	}()
+	fn()
+	return nil
+}
+
+// Go runs fn in a new goroutine. A panic in fn is reported to the hooks
+// instead of crashing the whole program — a recover in the PARENT
+// goroutine could never catch it.
+//
+// The hooks run after fn's own deferred calls: a defer wg.Done() in fn
+// can return from wg.Wait() before the panic is reported. Wait for a
+// panicking fn in the hook instead.
+func (r *Recoverer) Go(fn func()) {
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				r.capture(v)
+			}
+		}()
+		fn()
+	}()
+}
+
+// Handler wraps next so a panicking request gets a 500 response and is
+// reported, while the server keeps serving other requests. If next had
+// already written headers or body, the 500 can no longer be sent: the
+// panic is only reported.
+// http.ErrAbortHandler is re-panicked: net/http uses it to abort a
+// response on purpose.
+func (r *Recoverer) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		tw := &trackingWriter{ResponseWriter: w}
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if err, ok := v.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(v)
+			}
+			r.capture(v)
+			if !tw.wrote {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(tw, req)
+	})
+}
+
+// trackingWriter remembers whether the handler sent anything yet
+type trackingWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *trackingWriter) WriteHeader(code int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *trackingWriter) Write(p []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the original writer
+// (Flush, Hijack, deadlines)
+func (w *trackingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Default is used by the package-level functions. Until a hook is added
+// with OnPanic, it prints recovered panics to stderr.
+var Default = &Recoverer{}
+
+// OnPanic adds a hook to Default
+func OnPanic(hook Hook) { Default.OnPanic(hook) }
+
+// Do calls fn with Default
+func Do(fn func()) error { return Default.Do(fn) }
+
+// Go runs fn in a new goroutine with Default
+func Go(fn func()) { Default.Go(fn) }
+
+// Handler wraps next with Default
+func Handler(next http.Handler) http.Handler { return Default.Handler(next) }
@@ -0,0 +1,193 @@
+package safego
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// quiet sends hook-less reports to a buffer for the duration of the test
+func quiet(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	old := stderr
+	stderr = &buf
+	t.Cleanup(func() { stderr = old })
+	return &buf
+}
+
+func TestGo(t *testing.T) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var reported []*PanicError
+	r := New(func(pe *PanicError) {
+		mu.Lock()
+		reported = append(reported, pe)
+		mu.Unlock()
+		wg.Done() // the hook runs after fn's deferred calls: done only now
+	})
+
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		r.Go(func() {
+			if i%2 == 1 {
+				panic(fmt.Sprintf("worker %d failed", i))
+			}
+			results[i] = i * i
+			wg.Done()
+		})
+	}
+	wg.Wait()
+
+	if want := []int{0, 0, 4, 0, 16}; fmt.Sprint(results) != fmt.Sprint(want) {
+		t.Errorf("results = %v, want %v", results, want)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) != 2 {
+		t.Fatalf("%d panics reported, want 2", len(reported))
+	}
+	if !bytes.Contains(reported[0].Stack, []byte("safego.TestGo")) {
+		t.Errorf("stack does not point at the panicking closure:\n%s", reported[0].Stack)
+	}
+}
+
+func TestDo(t *testing.T) {
+	quiet(t)
+	var nilMap map[string]int
+	tests := []struct {
+		name     string
+		fn       func()
+		wantErr  bool
+		wantEOF  bool // errors.Is(err, io.EOF) through Unwrap
+		wantText string
+	}{
+		{name: "no panic", fn: func() {}},
+		{name: "panic(string)", fn: func() { panic("boom") }, wantErr: true, wantText: "panic: boom"},
+		{name: "panic(io.EOF)", fn: func() { panic(io.EOF) }, wantErr: true, wantEOF: true, wantText: "panic: EOF"},
+		{name: "nil map write", fn: func() { nilMap["a"] = 1 }, wantErr: true,
+			wantText: "panic: assignment to entry in nil map"},
+		{name: "panic(nil)", fn: func() { panic(nil) }, wantErr: true, // a real panic since Go 1.21
+			wantText: "panic: runtime error: panic called with nil argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Do(tt.fn)
+
+			var pe *PanicError
+			if errors.As(err, &pe) != tt.wantErr {
+				t.Fatalf("err = %v, want *PanicError: %v", err, tt.wantErr)
+			}
+			if errors.Is(err, io.EOF) != tt.wantEOF {
+				t.Errorf("errors.Is(%v, io.EOF) = %v, want %v", err, !tt.wantEOF, tt.wantEOF)
+			}
+			if tt.wantErr && !strings.HasPrefix(err.Error(), tt.wantText) {
+				t.Errorf("err = %q, want prefix %q", err, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestNoHooksPrintsToStderr(t *testing.T) {
+	buf := quiet(t)
+	r := &Recoverer{}
+
+	err := r.Do(func() { panic("nobody listening") })
+	if err == nil {
+		t.Fatal("Do returned nil after a panic")
+	}
+	out := buf.String()
+	if !strings.Contains(out, "safego: recovered panic: nobody listening") {
+		t.Errorf("stderr = %q, want the panic value", out)
+	}
+	if !strings.Contains(out, "TestNoHooksPrintsToStderr") {
+		t.Errorf("stderr has no stack:\n%s", out)
+	}
+
+	buf.Reset()
+	r.OnPanic(func(*PanicError) {})
+	_ = r.Do(func() { panic("hooked") })
+	if buf.Len() != 0 {
+		t.Errorf("a hook is registered, but stderr got %q", buf.String())
+	}
+}
+
+func TestHandler(t *testing.T) {
+	reports := 0
+	r := New(func(*PanicError) { reports++ })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "fine")
+	})
+	mux.HandleFunc("/panic", func(http.ResponseWriter, *http.Request) {
+		panic("handler bug")
+	})
+	mux.HandleFunc("/late-panic", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, "half a body")
+		panic("handler bug after writing")
+	})
+	h := r.Handler(mux)
+
+	tests := []struct {
+		path        string
+		wantStatus  int
+		wantBody    string
+		wantReports int
+	}{
+		{"/ok", http.StatusOK, "fine", 0},
+		{"/panic", http.StatusInternalServerError, "Internal Server Error\n", 1},
+		{"/ok", http.StatusOK, "fine", 1}, // still serving after the panic
+		// headers are gone already: no second WriteHeader, no 500 body
+		{"/late-panic", http.StatusAccepted, "half a body", 2},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.wantStatus || rec.Body.String() != tt.wantBody || reports != tt.wantReports {
+			t.Errorf("GET %s: status=%d body=%q reports=%d, want %d %q %d",
+				tt.path, rec.Code, rec.Body.String(), reports, tt.wantStatus, tt.wantBody, tt.wantReports)
+		}
+	}
+}
+
+func TestHandlerAbort(t *testing.T) {
+	quiet(t)
+	reports := 0
+	r := New(func(*PanicError) { reports++ })
+	abort := r.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	// http.ErrAbortHandler must reach net/http untouched
+	err := Do(func() {
+		abort.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	if !errors.Is(err, http.ErrAbortHandler) {
+		t.Errorf("err = %v, want http.ErrAbortHandler re-panicked", err)
+	}
+	if reports != 0 {
+		t.Errorf("reports = %d, want 0: aborting is not a bug", reports)
+	}
+}
+
+func TestHandlerResponseController(t *testing.T) {
+	h := New(func(*PanicError) {}).Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("Flush through the wrapper: %v", err)
+		}
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !rec.Flushed {
+		t.Error("the recorder was not flushed")
+	}
+}
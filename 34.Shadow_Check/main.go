@@ -0,0 +1,78 @@
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"go_projects/34.Shadow_Check/shadow"
+)
+
+/*
+====================================================
+🔹 SHADOW CHECK: FIND EVERY HIDDEN NAME
+====================================================
+
+Shadowing = an inner declaration with the same name as an outer one.
+Inside the inner scope the outer name can no longer be reached.
+
+The lessons do it on purpose:
+   5.Variable_Shadowing      a := 47          hides  var a = 10   (package)
+   10.Expression._Function   add := func...   hides  func add     (package)
+   20.Variadic_function      func print(...)  hides  print        (builtin)
+
+This command runs the `shadowall` analyzer (package ./shadow, built on
+golang.org/x/tools/go/analysis) on any set of packages and prints, for
+every finding, the INNER declaration (start of the line), the OUTER
+declaration with its scope, and the outer position again as a full path:
+
+   .../5.Variable_Shadowing/main.go:20:3: var a shadows var a declared at main.go:7:5 (package scope)
+   .../5.Variable_Shadowing/main.go:7:5: 	shadowed declaration
+   .../20.Variadic_function/main.go:6:6: func print shadows builtin print (universe scope)
+
+Scopes, from outside in:
+   universe → package → file (imports) → function → block → block ...
+
+Run it on every lesson:
+    go run ./34.Shadow_Check ./...
+
+One lesson, or machine-readable output (includes the related position):
+    go run ./34.Shadow_Check ./5.Variable_Shadowing
+    go run ./34.Shadow_Check -json ./...
+
+4.Scope is its own module; run it from there:
+    cd 4.Scope && go run ../34.Shadow_Check ./...    ❌ (outside this module)
+    go build -o /tmp/shadowall ./34.Shadow_Check && (cd 4.Scope && /tmp/shadowall ./...)   ✅
+
+Exit status is 3 when something was found (like go vet), so
+`go run` ends with "exit status 3" — that is expected.
+====================================================
+*/
+
+func main() {
+	multichecker.Main(shadow.Analyzer)
+}
+
+/*
+====================================================
+🔹 WHY IT MATTERS
+====================================================
+
+1) The classic bug:
+       var err error
+       if cond {
+           x, err := f()   // NEW err, the outer one stays nil
+           ...
+       }
+       return err          // always nil
+
+2) Shadowing a builtin (print, len, new, string, error ...) is legal
+   but surprising: inside that scope the builtin is gone.
+
+3) Shadowing an import (`fmt := ...`) makes the package unreachable
+   in that scope.
+
+4) Not every shadow is a bug (`ctx, cancel := context.WithTimeout(ctx, d)`
+   is idiomatic). That is why `go vet` does not run its shadow check by
+   default, and why this tool only REPORTS, with both positions, so you
+   can decide.
+====================================================
+*/
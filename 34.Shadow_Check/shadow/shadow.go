@@ -0,0 +1,122 @@
+// Package shadow defines an analyzer that reports every declaration that
+// hides another one with the same name (5.Variable_Shadowing: `a := 47`
+// hiding the global a, 10.Expression._Function: a local `add := func...`
+// hiding the package-level add, 20.Variadic_function: `func print`
+// hiding the builtin print).
+//
+// Unlike the stock vet shadow check it does not try to guess which
+// shadows are bugs: it reports all of them, with both positions, because
+// the point is to SEE how scopes nest.
+package shadow
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzer reports shadowed identifiers
+var Analyzer = &analysis.Analyzer{
+	Name: "shadowall",
+	Doc:  "report declarations that shadow a variable, function, constant, type, import or builtin",
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	// Defs is a map: sort so findings come out in source order
+	idents := make([]token.Pos, 0, len(pass.TypesInfo.Defs))
+	objs := make(map[token.Pos]types.Object, len(pass.TypesInfo.Defs))
+	for id, obj := range pass.TypesInfo.Defs {
+		if obj == nil || id.Name == "_" {
+			continue // package clause, type switch symbolic variable, blank
+		}
+		idents = append(idents, id.Pos())
+		objs[id.Pos()] = obj
+	}
+	sort.Slice(idents, func(i, j int) bool { return idents[i] < idents[j] })
+
+	for _, pos := range idents {
+		inner := objs[pos]
+		if v, ok := inner.(*types.Var); ok && v.IsField() {
+			continue // struct fields live in their own namespace
+		}
+		scope := inner.Parent()
+		if scope == nil {
+			continue // methods are not in any scope
+		}
+
+		var outer types.Object
+		if scope == pass.Pkg.Scope() {
+			// package-level names can only hide the universe
+			outer = types.Universe.Lookup(inner.Name())
+		} else if scope.Parent() != nil {
+			// pos matters in function scopes: a name declared further down
+			// is not visible yet, so it cannot be shadowed
+			_, outer = scope.Parent().LookupParent(inner.Name(), pos)
+		}
+		if outer == nil {
+			continue
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     pos,
+			End:     pos + token.Pos(len(inner.Name())),
+			Message: fmt.Sprintf("%s %s shadows %s", kind(inner), inner.Name(), where(pass.Fset, outer)),
+			Related: related(outer),
+		})
+	}
+	return nil, nil
+}
+
+// kind names an object the way its declaration reads
+func kind(obj types.Object) string {
+	switch obj := obj.(type) {
+	case *types.Var:
+		return "var"
+	case *types.Const:
+		return "const"
+	case *types.TypeName:
+		return "type"
+	case *types.Func:
+		return "func"
+	case *types.PkgName:
+		return "import"
+	default:
+		return fmt.Sprintf("%T", obj)
+	}
+}
+
+// where describes the outer declaration: its kind, scope and position
+func where(fset *token.FileSet, outer types.Object) string {
+	if outer.Parent() == types.Universe {
+		return fmt.Sprintf("builtin %s (universe scope)", outer.Name())
+	}
+	level := "function"
+	switch {
+	case outer.Pkg() != nil && outer.Parent() == outer.Pkg().Scope():
+		level = "package"
+	case isFileScope(outer):
+		level = "file"
+	}
+	p := fset.Position(outer.Pos())
+	return fmt.Sprintf("%s %s declared at %s:%d:%d (%s scope)",
+		kind(outer), outer.Name(), filepath.Base(p.Filename), p.Line, p.Column, level)
+}
+
+// isFileScope reports whether obj is an import: the only thing declared
+// in a file scope
+func isFileScope(obj types.Object) bool {
+	_, ok := obj.(*types.PkgName)
+	return ok
+}
+
+func related(outer types.Object) []analysis.RelatedInformation {
+	if !outer.Pos().IsValid() {
+		return nil // builtins have no position
+	}
+	return []analysis.RelatedInformation{{Pos: outer.Pos(), Message: "shadowed declaration"}}
+}
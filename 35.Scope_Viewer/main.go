@@ -0,0 +1,453 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+/*
+====================================================
+🔹 SCOPE VIEWER: WHERE IS A NAME VISIBLE?
+====================================================
+
+4.Scope, 4.1Another_Scope_Example and 5.Variable_Shadowing explain scope
+in comments. This command asks the type checker (go/types, loaded with
+golang.org/x/tools/go/packages) instead and
+prints, for one package:
+
+   1. the SCOPE TREE
+        universe → package → file → func → block → block ...
+      with the names declared in every scope,
+   2. every IDENTIFIER USE and the scope it resolved to
+      (a name is looked up from the innermost scope outwards),
+   3. for every local name: what happens if you write it in another
+      function (-from, default main) or after its block ended:
+        ✗ undefined               → a compile error
+        ➜ means <other> instead   → compiles, but it is a different variable!
+
+Usage:
+    go run ./35.Scope_Viewer [-from main] [-use name@func] <package dir>...
+
+Examples:
+    go run ./35.Scope_Viewer ./4.1Another_Scope_Example
+    go run ./35.Scope_Viewer ./5.Variable_Shadowing
+
+4.Scope's commented-out `add (z, a)`: z lives in mathlib.Add, main is in
+another package. -use checks one name from one function across every
+package given on the command line:
+    go run ./35.Scope_Viewer -use z@main ./4.Scope ./4.Scope/mathlib
+====================================================
+*/
+
+// pkg is one type-checked package
+type pkg struct {
+	dir   string
+	fset  *token.FileSet
+	files []*ast.File
+	types *types.Package
+	info  *types.Info
+	// labels describes every scope that belongs to a syntax node
+	labels map[*types.Scope]string
+	funcs  map[string]*ast.FuncDecl
+}
+
+func main() {
+	from := flag.String("from", "main", "function used to probe every local name")
+	use := flag.String("use", "", "resolve name@func, e.g. z@main, across all packages")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: scopeview [-from main] [-use name@func] <package dir>...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var pkgs []*pkg
+	for _, dir := range flag.Args() {
+		p, err := load(dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "scopeview:", err)
+			os.Exit(1)
+		}
+		pkgs = append(pkgs, p)
+	}
+
+	if *use != "" {
+		name, fn, ok := strings.Cut(*use, "@")
+		if !ok {
+			fmt.Fprintln(os.Stderr, "scopeview: -use wants name@func")
+			os.Exit(2)
+		}
+		resolveUse(pkgs, name, fn)
+		return
+	}
+
+	for _, p := range pkgs {
+		p.printTree()
+		p.printUses()
+		p.printProbes(*from)
+	}
+}
+
+// load parses and type-checks the package in dir. Type errors are
+// printed but do not stop the tool: the scopes are still useful.
+func load(dir string) (*pkg, error) {
+	// go/packages runs the go command IN dir, so imports resolve from the
+	// package's own module (4.Scope is a separate module)
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo,
+		Dir: abs,
+	}
+	loaded, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(loaded) != 1 {
+		return nil, fmt.Errorf("%s: expected one package, got %d", dir, len(loaded))
+	}
+	lp := loaded[0]
+	for _, e := range lp.Errors {
+		fmt.Println("⚠", e)
+	}
+	if len(lp.Syntax) == 0 {
+		return nil, fmt.Errorf("no .go files in %s", dir)
+	}
+
+	p := &pkg{dir: dir, fset: lp.Fset, files: lp.Syntax, types: lp.Types, info: lp.TypesInfo,
+		funcs: map[string]*ast.FuncDecl{}}
+	for _, f := range p.files {
+		for _, decl := range f.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Recv == nil {
+				p.funcs[fd.Name.Name] = fd
+			}
+		}
+	}
+	p.labels = scopeLabels(p.info, p.files, p.fset)
+	return p, nil
+}
+
+// scopeLabels names every scope after the syntax that opened it
+func scopeLabels(info *types.Info, files []*ast.File, fset *token.FileSet) map[*types.Scope]string {
+	funcNames := map[*ast.FuncType]string{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				name := fd.Name.Name
+				if fd.Recv != nil && len(fd.Recv.List) == 1 {
+					name = "(" + types.ExprString(fd.Recv.List[0].Type) + ")." + name
+				}
+				funcNames[fd.Type] = name
+			}
+		}
+	}
+
+	labels := map[*types.Scope]string{}
+	for node, scope := range info.Scopes {
+		var label string
+		switch n := node.(type) {
+		case *ast.File:
+			label = "file " + filepath.Base(fset.Position(n.Pos()).Filename)
+		case *ast.FuncType:
+			if name, ok := funcNames[n]; ok {
+				label = "func " + name
+			} else {
+				label = "func literal"
+			}
+		case *ast.BlockStmt:
+			label = "block { }"
+		case *ast.IfStmt:
+			label = "if"
+		case *ast.ForStmt:
+			label = "for"
+		case *ast.RangeStmt:
+			label = "for range"
+		case *ast.SwitchStmt:
+			label = "switch"
+		case *ast.TypeSwitchStmt:
+			label = "type switch"
+		case *ast.CaseClause:
+			label = "case"
+		case *ast.CommClause:
+			label = "select case"
+		default:
+			label = fmt.Sprintf("%T", node)
+		}
+		labels[scope] = label
+	}
+	return labels
+}
+
+// scopeName describes any scope, including the universe and the scopes
+// of imported packages
+func (p *pkg) scopeName(s *types.Scope) string {
+	switch {
+	case s == nil:
+		return "no scope (field or method)"
+	case s == types.Universe:
+		return "universe (builtin)"
+	case p.types != nil && s == p.types.Scope():
+		return "package " + p.types.Name()
+	}
+	if label, ok := p.labels[s]; ok {
+		return label
+	}
+	if s.Parent() == types.Universe {
+		return "package (imported)"
+	}
+	return "scope"
+}
+
+func (p *pkg) pos(pos token.Pos) string {
+	if !pos.IsValid() {
+		return "-"
+	}
+	position := p.fset.Position(pos)
+	return fmt.Sprintf("%s:%d:%d", filepath.Base(position.Filename), position.Line, position.Column)
+}
+
+// ----------------------------
+// 1. Scope tree
+// ----------------------------
+func (p *pkg) printTree() {
+	fmt.Printf("🔹 Scope tree of %s\n", p.dir)
+	fmt.Printf("universe  (%d names: %s ...)\n", len(types.Universe.Names()), strings.Join(types.Universe.Names()[:8], " "))
+	if p.types == nil {
+		return
+	}
+	fmt.Printf("└─ package %s  %s\n", p.types.Name(), p.names(p.types.Scope()))
+
+	// file scopes are children of the package scope; sort them by file
+	files := make([]*types.Scope, 0, p.types.Scope().NumChildren())
+	for i := 0; i < p.types.Scope().NumChildren(); i++ {
+		files = append(files, p.types.Scope().Child(i))
+	}
+	sort.Slice(files, func(i, j int) bool { return p.labels[files[i]] < p.labels[files[j]] })
+	for i, s := range files {
+		p.printScope(s, "   ", i == len(files)-1)
+	}
+	fmt.Println()
+}
+
+func (p *pkg) printScope(s *types.Scope, indent string, last bool) {
+	branch, next := "├─ ", "│  "
+	if last {
+		branch, next = "└─ ", "   "
+	}
+	lines := ""
+	if s.Pos().IsValid() {
+		lines = fmt.Sprintf("[lines %d-%d]", p.fset.Position(s.Pos()).Line, p.fset.Position(s.End()).Line)
+	}
+	fmt.Printf("%s%s%-16s %-14s %s\n", indent, branch, p.labels[s], lines, p.names(s))
+	for i := 0; i < s.NumChildren(); i++ {
+		p.printScope(s.Child(i), indent+next, i == s.NumChildren()-1)
+	}
+}
+
+// names lists the names declared in s in source order, with positions
+func (p *pkg) names(s *types.Scope) string {
+	objs := make([]types.Object, 0, s.Len())
+	for _, name := range s.Names() {
+		objs = append(objs, s.Lookup(name))
+	}
+	sort.Slice(objs, func(i, j int) bool { return objs[i].Pos() < objs[j].Pos() })
+	var out []string
+	for _, obj := range objs {
+		line := p.fset.Position(obj.Pos()).Line
+		out = append(out, fmt.Sprintf("%s(%d)", obj.Name(), line))
+	}
+	return strings.Join(out, " ")
+}
+
+// ----------------------------
+// 2. Identifier resolution
+// ----------------------------
+func (p *pkg) printUses() {
+	fmt.Println("🔹 Identifier uses → scope they resolve to")
+	ids := make([]*ast.Ident, 0, len(p.info.Uses))
+	for id := range p.info.Uses {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Pos() < ids[j].Pos() })
+
+	for _, id := range ids {
+		obj := p.info.Uses[id]
+		declared := ""
+		if obj.Pkg() == p.types {
+			declared = "declared at " + p.pos(obj.Pos())
+		} else if obj.Pkg() != nil {
+			declared = "from " + obj.Pkg().Path()
+		}
+		fmt.Printf("  %-14s %-12s → %-22s %s\n", p.pos(id.Pos()), id.Name, p.scopeName(obj.Parent()), declared)
+	}
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. "What if I used it over there?"
+// ----------------------------
+
+// lookupAt resolves name as if it were written at pos
+func (p *pkg) lookupAt(name string, pos token.Pos) types.Object {
+	inner := p.types.Scope().Innermost(pos)
+	if inner == nil {
+		return nil
+	}
+	_, obj := inner.LookupParent(name, pos)
+	return obj
+}
+
+func (p *pkg) printProbes(from string) {
+	if p.types == nil {
+		return
+	}
+	fmt.Printf("🔹 Local names used outside their scope (from func %s, or after their block ends)\n", from)
+
+	var locals []types.Object
+	for id, obj := range p.info.Defs {
+		if obj == nil || id.Name == "_" || obj.Parent() == nil {
+			continue
+		}
+		switch obj.Parent() {
+		case p.types.Scope(), types.Universe:
+			continue
+		}
+		if _, isPkg := obj.(*types.PkgName); isPkg {
+			continue
+		}
+		locals = append(locals, obj)
+	}
+	sort.Slice(locals, func(i, j int) bool { return locals[i].Pos() < locals[j].Pos() })
+
+	target := p.funcs[from]
+	found := false
+	for _, obj := range locals {
+		scope := obj.Parent()
+		var probes []token.Pos
+		if target != nil && target.Body != nil && !scope.Contains(target.Body.Rbrace) {
+			probes = append(probes, target.Body.Rbrace)
+		}
+		// in its own function, right after the block that declared it
+		if owner := p.enclosingFunc(obj.Pos()); owner != nil && owner != target &&
+			owner.Body != nil && !scope.Contains(owner.Body.Rbrace) {
+			probes = append(probes, owner.Body.Rbrace)
+		}
+
+		for _, at := range probes {
+			found = true
+			where := "in func " + p.enclosingFunc(at).Name.Name
+			if p.enclosingFunc(at) == p.enclosingFunc(obj.Pos()) {
+				where = fmt.Sprintf("after line %d", p.fset.Position(scope.End()).Line)
+			}
+			got := p.lookupAt(obj.Name(), at)
+			fmt.Printf("  %-10s declared in %-14s at %-14s used %-16s %s\n",
+				obj.Name(), p.scopeName(scope), p.pos(obj.Pos()), where, p.verdict(got))
+		}
+	}
+	if !found {
+		fmt.Println("  (none: no local names, or no func", from+")")
+	}
+	fmt.Println()
+}
+
+func (p *pkg) verdict(got types.Object) string {
+	if got == nil {
+		return "✗ undefined (out of scope)"
+	}
+	declared := p.scopeName(got.Parent())
+	if got.Pkg() == p.types && got.Pos().IsValid() {
+		declared += " at " + p.pos(got.Pos())
+	}
+	return fmt.Sprintf("➜ means %s from %s", got.Name(), declared)
+}
+
+// enclosingFunc returns the top-level function containing pos
+func (p *pkg) enclosingFunc(pos token.Pos) *ast.FuncDecl {
+	for _, f := range p.files {
+		for _, decl := range f.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Pos() <= pos && pos <= fd.End() {
+				return fd
+			}
+		}
+	}
+	return nil
+}
+
+// resolveUse answers "can I write name inside fn?" and, when the answer
+// is no, lists where that name IS declared in all loaded packages
+func resolveUse(pkgs []*pkg, name, fn string) {
+	fmt.Printf("🔹 %s used at the end of func %s\n", name, fn)
+	resolved := false
+	for _, p := range pkgs {
+		fd, ok := p.funcs[fn]
+		if !ok || fd.Body == nil || p.types == nil {
+			continue
+		}
+		resolved = true
+		fmt.Printf("  in package %s (%s): %s\n", p.types.Name(), p.dir, p.verdict(p.lookupAt(name, fd.Body.Rbrace)))
+	}
+	if !resolved {
+		fmt.Printf("  func %s not found\n", fn)
+	}
+
+	fmt.Printf("🔹 Declarations of %s\n", name)
+	for _, p := range pkgs {
+		for id, obj := range p.info.Defs {
+			if obj == nil || id.Name != name {
+				continue
+			}
+			owner := ""
+			if fd := p.enclosingFunc(obj.Pos()); fd != nil && p.scopeName(obj.Parent()) != "func "+fd.Name.Name {
+				owner = " inside func " + fd.Name.Name
+			}
+			fmt.Printf("  package %-10s %-16s %s%s\n", p.types.Name(), p.pos(obj.Pos()), p.scopeName(obj.Parent()), owner)
+		}
+	}
+}
+
+/*
+====================================================
+🔹 READING THE OUTPUT
+====================================================
+
+go run ./35.Scope_Viewer -use z@main ./4.Scope ./4.Scope/mathlib
+
+   🔹 z used at the end of func main
+     in package main (./4.Scope): ✗ undefined (out of scope)
+   🔹 Declarations of z
+     package mathlib    math.go:6:2      func Add
+
+→ z exists, but only in the scope of func Add (in another package,
+  even). `add (z, a)` in main would not compile: "undefined: z".
+
+go run ./35.Scope_Viewer ./5.Variable_Shadowing
+
+     a          declared in block { }      at main.go:20:3   used after line 24    ➜ means a from package main at main.go:7:5
+
+→ the block { } is the body of the if. NO compile error: after the if block, `a` silently means the GLOBAL a.
+  That is shadowing (see 34.Shadow_Check).
+
+Rules the tree makes visible:
+   - Lookup goes innermost → outermost, the first match wins.
+   - Parameters live in the func scope, together with the top-level
+     locals of the body.
+   - `if x := ...`, `for i := ...` and every `case` open their own scope.
+   - Imports are FILE scoped: another file of the same package must
+     import fmt again.
+====================================================
+*/
@@ -0,0 +1,16 @@
+package main
+
+import "go_projects/36.Init_Order/trace"
+
+// A is the first variable of package main (a.go sorts first), but it
+// needs Z from z.go, so Z is initialized before it
+var A = trace.Value("main.A = Z + 1        (a.go)", Z+1)
+
+// A file may have several init functions: they run top to bottom
+func init() {
+	trace.Step("main: init() #1 in a.go")
+}
+
+func init() {
+	trace.Step("main: init() #2 in a.go")
+}
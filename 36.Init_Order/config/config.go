@@ -0,0 +1,15 @@
+// Package config has package-level variables that depend on each other
+// ACROSS its two files, and one init() per file.
+package config
+
+import "go_projects/36.Init_Order/trace"
+
+// Port is declared first but needs Base (defaults.go), so it waits
+var Port = trace.Value("config.Port = Base + 80", Base+80)
+
+// Debug depends on nothing: it is the first variable that is ready
+var Debug = trace.Value("config.Debug", 1)
+
+func init() {
+	trace.Step("config: init() in config.go")
+}
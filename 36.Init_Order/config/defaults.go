@@ -0,0 +1,10 @@
+package config
+
+import "go_projects/36.Init_Order/trace"
+
+// Base is declared in the second file, yet initialized before Port
+var Base = trace.Value("config.Base", 8000)
+
+func init() {
+	trace.Step("config: init() in defaults.go")
+}
@@ -0,0 +1,15 @@
+// Package db imports config, so config is completely initialized
+// (variables AND init functions) before anything in db runs.
+package db
+
+import (
+	"go_projects/36.Init_Order/config"
+	"go_projects/36.Init_Order/trace"
+)
+
+// Conns can safely read config.Port: config is done
+var Conns = trace.Value("db.Conns = config.Port / 1000", config.Port/1000)
+
+func init() {
+	trace.Step("db: init()")
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+====================================================
+🔹 INITTRACE SUMMARY
+====================================================
+
+With GODEBUG=inittrace=1 the runtime prints one line to stderr for every
+package it initializes, in the order it ran them:
+
+    init go_projects/36.Init_Order/config @0.72 ms, 0.008 ms clock, 512 bytes, 17 allocs
+         package                          start    time in its      heap allocated by
+                                          (since   vars + inits     its vars + inits
+                                          startup)
+
+This command reads those lines and prints the order, which packages are
+yours, the slowest / most allocating inits, and when main() could start.
+
+Usage:
+    go run ./36.Init_Order/inittrace [-top 5] <package dir>   builds, runs, summarizes
+    <program> 2>&1 >/dev/null | go run ./36.Init_Order/inittrace   reads stdin
+
+Examples:
+    go run ./36.Init_Order/inittrace ./36.Init_Order
+    go run ./36.Init_Order/inittrace ./8.Init_Function
+
+Do NOT write `GODEBUG=inittrace=1 go run ...`: the go command itself
+would print its own trace. Build first, or let this tool do it.
+====================================================
+*/
+
+// record is one "init ..." line
+type record struct {
+	pkg    string
+	start  float64 // ms since the runtime started
+	clock  float64 // ms spent in the package's initialization
+	bytes  int64
+	allocs int64
+}
+
+var initLine = regexp.MustCompile(`^init (\S+) @([\d.]+) ms, ([\d.]+) ms clock, (\d+) bytes, (\d+) allocs$`)
+
+func main() {
+	top := flag.Int("top", 5, "number of packages in the slowest / most allocating lists")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: inittrace [-top 5] [package dir]   (no dir: read GODEBUG=inittrace=1 output from stdin)")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *top < 1 {
+		fmt.Fprintln(os.Stderr, "inittrace: -top must be at least 1")
+		os.Exit(2)
+	}
+
+	var in io.Reader = os.Stdin
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		out, err := traceRun(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "inittrace:", err)
+			os.Exit(1)
+		}
+		in = bytes.NewReader(out)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	records, err := parse(in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "inittrace:", err)
+		os.Exit(1)
+	}
+	if len(records) == 0 {
+		fmt.Fprintln(os.Stderr, "inittrace: no \"init ...\" lines found (was GODEBUG=inittrace=1 set?)")
+		os.Exit(1)
+	}
+	summarize(records, stdPackages(), *top)
+}
+
+// traceRun builds the package in dir and runs it with GODEBUG=inittrace=1.
+// The program's stdout is discarded, its stderr (the trace) is returned.
+func traceRun(dir string) ([]byte, error) {
+	if !strings.HasPrefix(dir, ".") && !filepath.IsAbs(dir) {
+		dir = "./" + dir // a directory, not an import path
+	}
+	tmp, err := os.MkdirTemp("", "inittrace")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	bin := filepath.Join(tmp, "prog")
+	if out, err := exec.Command("go", "build", "-o", bin, dir).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("go build %s: %v\n%s", dir, err, out)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(bin)
+	cmd.Env = append(os.Environ(), "GODEBUG=inittrace=1")
+	cmd.Stderr = &stderr
+	// a failing program still has a complete trace of its initialization
+	_ = cmd.Run()
+	return stderr.Bytes(), nil
+}
+
+func parse(r io.Reader) ([]record, error) {
+	var records []record
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		m := initLine.FindStringSubmatch(strings.TrimSpace(sc.Text()))
+		if m == nil {
+			continue
+		}
+		start, _ := strconv.ParseFloat(m[2], 64)
+		clock, _ := strconv.ParseFloat(m[3], 64)
+		b, _ := strconv.ParseInt(m[4], 10, 64)
+		a, _ := strconv.ParseInt(m[5], 10, 64)
+		records = append(records, record{pkg: m[1], start: start, clock: clock, bytes: b, allocs: a})
+	}
+	return records, sc.Err()
+}
+
+// stdPackages asks the go command for the standard library's import
+// paths; without it every package counts as "yours"
+func stdPackages() map[string]bool {
+	std := map[string]bool{}
+	out, err := exec.Command("go", "list", "std").Output()
+	if err != nil {
+		return std
+	}
+	for _, path := range strings.Fields(string(out)) {
+		std[path] = true
+	}
+	return std
+}
+
+func summarize(records []record, std map[string]bool, top int) {
+	var clock float64
+	var allocBytes, allocs int64
+	var yours []record
+	for _, r := range records {
+		clock += r.clock
+		allocBytes += r.bytes
+		allocs += r.allocs
+		if !std[r.pkg] {
+			yours = append(yours, r)
+		}
+	}
+	last := records[len(records)-1]
+
+	fmt.Println("🔹 Initialization order")
+	fmt.Printf("  %3s  %-44s %10s %10s %10s %8s\n", "#", "package", "start ms", "clock ms", "bytes", "allocs")
+	for i, r := range records {
+		mark := " "
+		if !std[r.pkg] {
+			mark = "★"
+		}
+		fmt.Printf("  %3d %s%-44s %10.3f %10.3f %10d %8d\n", i+1, mark, r.pkg, r.start, r.clock, r.bytes, r.allocs)
+	}
+	fmt.Println("  ★ = not in the standard library (your packages, dependencies, main)")
+
+	fmt.Println("\n🔹 Totals")
+	fmt.Printf("  packages initialized  %d (%d yours)\n", len(records), len(yours))
+	fmt.Printf("  time in inits         %.3f ms\n", clock)
+	fmt.Printf("  allocated by inits    %d bytes in %d allocs\n", allocBytes, allocs)
+	fmt.Printf("  main() starts after   ~%.3f ms\n", last.start+last.clock)
+
+	by := func(title string, less func(a, b record) bool, value func(r record) string) {
+		sorted := append([]record(nil), records...)
+		sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+		fmt.Printf("\n🔹 %s\n", title)
+		for _, r := range sorted[:min(top, len(sorted))] {
+			fmt.Printf("  %-46s %s\n", r.pkg, value(r))
+		}
+	}
+	by("Slowest inits", func(a, b record) bool { return a.clock > b.clock },
+		func(r record) string { return fmt.Sprintf("%.3f ms", r.clock) })
+	by("Most allocating inits", func(a, b record) bool { return a.bytes > b.bytes },
+		func(r record) string { return fmt.Sprintf("%d bytes, %d allocs", r.bytes, r.allocs) })
+}
+
+/*
+====================================================
+🔹 READING THE SUMMARY
+====================================================
+
+- Only packages with something to do (package-level variables with
+  initializers, or init functions) appear. fmt itself may be missing.
+- The order is the one 36.Init_Order explains: dependencies first, then
+  import path order; main is always last.
+- "clock" includes ALL of a package's variable initializers and init()
+  functions. A big number there is paid by every run of the program,
+  before main() — e.g. compiling regexps or building tables at init.
+- Fix slow inits by doing the work lazily (sync.OnceValue) the first
+  time it is needed.
+====================================================
+*/
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"go_projects/36.Init_Order/config"
+	"go_projects/36.Init_Order/trace"
+)
+
+/*
+====================================================
+🔹 INITIALIZATION ORDER: WHAT RUNS BEFORE main()?
+====================================================
+
+8.Init_Function says init() "runs before main()". 6.Function_Types has
+`var iife = func...()` that runs even earlier. The full rule:
+
+   1. IMPORTED PACKAGES first, each one completely (vars, then inits),
+      each one only ONCE — no matter how many packages import it.
+      A package is initialized after everything it imports.
+   2. PACKAGE-LEVEL VARIABLES, by DEPENDENCY ANALYSIS:
+      repeatedly pick the earliest variable in declaration order
+      (files in the order given to the compiler = sorted by name)
+      that does not depend on an uninitialized variable.
+   3. init() FUNCTIONS, in file order, top to bottom inside a file.
+   4. main()
+
+This lesson is spread over several files and packages:
+
+   trace/trace.go          records every step (imported by everyone)
+   config/config.go        Port = Base + 80, Debug = 1, init()
+   config/defaults.go      Base = 8000, init()
+   db/db.go                imports config: Conns = config.Port / 1000, init()
+   a.go                    A = Z + 1, two init()s
+   main.go                 iife, init(), main()
+   z.go                    imports db: Z = db.Conns, init()
+
+Every initializer goes through trace.Value / trace.Step, so the program
+prints the REAL order, then checks it against the rules above.
+
+Run it:
+    go run ./36.Init_Order
+
+What the runtime says (one line per initialized package, with timings):
+    go run ./36.Init_Order/inittrace ./36.Init_Order
+====================================================
+*/
+
+// iife is 6.Function_Types' immediately invoked function: the call is
+// the variable's initializer, so it runs with the other variables
+var iife = func() int {
+	return trace.Value("main.iife = func(){...}() (main.go)", 42)
+}()
+
+func init() {
+	trace.Step("main: init() in main.go")
+}
+
+// want is the order the rules predict
+var want = []string{
+	// trace has no dependencies: it goes first
+	"trace: init()",
+
+	// config: Port is declared first but waits for Base
+	"var config.Debug = 1",
+	"var config.Base = 8000",
+	"var config.Port = Base + 80 = 8080",
+	"config: init() in config.go",
+	"config: init() in defaults.go",
+
+	// db imports config, which is already done
+	"var db.Conns = config.Port / 1000 = 8",
+	"db: init()",
+
+	// main: iife is the first variable that is ready, then Z, then A
+	"var main.iife = func(){...}() (main.go) = 42",
+	"var main.Z = db.Conns     (z.go) = 8",
+	"var main.A = Z + 1        (a.go) = 9",
+	"main: init() #1 in a.go",
+	"main: init() #2 in a.go",
+	"main: init() in main.go",
+	"main: init() in z.go",
+
+	"main()",
+}
+
+func main() {
+	trace.Step("main()")
+	fmt.Println()
+
+	got := trace.Steps()
+	failed := 0
+	for i := range max(len(got), len(want)) {
+		var g, w string
+		if i < len(got) {
+			g = got[i]
+		}
+		if i < len(want) {
+			w = want[i]
+		}
+		if g != w {
+			fmt.Printf("❌ step %d: got %q, want %q\n", i+1, g, w)
+			failed++
+		}
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+	fmt.Printf("✅ all %d steps in the expected order (config.Port = %d, A = %d)\n", len(got), config.Port, A)
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+Output:
+   1. trace: init()
+   2. var config.Debug = 1
+   3. var config.Base = 8000
+   4. var config.Port = Base + 80 = 8080
+   5. config: init() in config.go
+   6. config: init() in defaults.go
+   7. var db.Conns = config.Port / 1000 = 8
+   8. db: init()
+   9. var main.iife = func(){...}() (main.go) = 42
+  10. var main.Z = db.Conns     (z.go) = 8
+  11. var main.A = Z + 1        (a.go) = 9
+  12. main: init() #1 in a.go
+  13. main: init() #2 in a.go
+  14. main: init() in main.go
+  15. main: init() in z.go
+  16. main()
+
+1) Variables are NOT initialized top to bottom:
+   config.Debug (2nd in config.go) runs before config.Port (1st),
+   because Port needs Base from the other file.
+
+2) ALL variables of a package are ready before its FIRST init() runs,
+   so init() may use any of them (8.Init_Function's `a`).
+
+3) init() order = file name order. Renaming a.go to x.go changes the
+   output — another reason not to let inits depend on each other.
+
+4) Imported packages are fully initialized first, and only once:
+   config is imported by main AND by db, but initialized once.
+   Since Go 1.21 independent packages are initialized in import path
+   order, so the order is the same for every build.
+
+5) Dependency cycles between package-level variables are compile
+   errors ("initialization cycle"), and import cycles are not allowed.
+====================================================
+*/
@@ -0,0 +1,34 @@
+// Package trace records every initialization step of the 36.Init_Order
+// lesson, in the order the Go runtime actually runs them.
+package trace
+
+import "fmt"
+
+var steps []string
+
+// Step logs one initialization step and returns its number
+func Step(what string) int {
+	steps = append(steps, what)
+	fmt.Printf("  %2d. %s\n", len(steps), what)
+	return len(steps)
+}
+
+// Value logs the initialization of a package-level variable and returns
+// v, so it can wrap any initializer:
+//
+//	var a = trace.Value("main.a", b+1)
+func Value(what string, v int) int {
+	Step(fmt.Sprintf("var %s = %d", what, v))
+	return v
+}
+
+// Steps returns every step recorded so far
+func Steps() []string {
+	return append([]string(nil), steps...)
+}
+
+// trace has no dependencies of its own besides fmt, so it is initialized
+// before every package that imports it
+func init() {
+	Step("trace: init()")
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"go_projects/36.Init_Order/db"
+	"go_projects/36.Init_Order/trace"
+)
+
+// Z is declared in the LAST file, but A depends on it
+var Z = trace.Value("main.Z = db.Conns     (z.go)", db.Conns)
+
+func init() {
+	trace.Step("main: init() in z.go")
+}
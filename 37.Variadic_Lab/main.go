@@ -0,0 +1,207 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	v "go_projects/37.Variadic_Lab/variadic"
+)
+
+/*
+====================================================
+🔹 VARIADIC FUNCTIONS: WHAT `...` REALLY PASSES
+====================================================
+
+20.Variadic_function shows:
+    func print(numbers ...int)   // numbers is a []int
+    print(5, 6, 7, 8, 9)
+
+(careful: that `print` shadows the builtin print — see 34.Shadow_Check)
+
+Two ways to call a variadic function:
+    f(1, 2, 3)   → Go builds a NEW slice [1 2 3] for the call
+    f(s...)      → NO new slice: f gets s itself (same pointer, len, cap)
+
+So with f(s...) every write inside f is a write into the CALLER's array.
+
+This lab covers:
+   1. aliasing through s...
+   2. generic variadic helpers
+   3. functional options (...Option), the idiomatic real-world use
+   4. the allocation cost of variadic calls vs slice parameters
+
+The expected results of 1-3 (and the allocation counts of 4) are
+table tests in variadic/variadic_test.go.
+
+Run it:
+    go run ./37.Variadic_Lab
+    go run ./37.Variadic_Lab -benchtime=500ms
+    go test ./37.Variadic_Lab/...
+====================================================
+*/
+
+// ----------------------------
+// 1. Aliasing
+// ----------------------------
+func aliasing() {
+	fmt.Println("🔹 1. f(s...) shares the caller's backing array")
+
+	s := []int{1, 2, 3}
+	v.Scale(10, s...)
+	fmt.Println("  Scale(10, s...)            s =", s, "← changed")
+
+	a, b, c := 1, 2, 3
+	v.Scale(10, a, b, c)
+	fmt.Println("  Scale(10, a, b, c)         a, b, c =", a, b, c, "← untouched")
+
+	s = []int{1, 2, 3}
+	safe := v.ScaleCopy(10, s...)
+	fmt.Println("  ScaleCopy(10, s...)        s =", s, " out =", safe)
+
+	// append inside the callee: depends on the capacity it received
+	s = []int{1, 2, 3}
+	out := v.AppendOne(s[:2]...)
+	fmt.Println("  AppendOne(s[:2]...)        s =", s, " out =", out, "← s[2] overwritten!")
+
+	s = []int{1, 2, 3}
+	out = v.AppendOne(s...)
+	out[0] = -1
+	fmt.Println("  AppendOne(s...), out[0]=-1 s =", s, " out =", out, "← len==cap: copied")
+
+	// a full slice expression caps the capacity → append must copy
+	s = []int{1, 2, 3}
+	out = v.AppendOne(s[:2:2]...)
+	fmt.Println("  AppendOne(s[:2:2]...)      s =", s, " out =", out)
+
+	// no arguments at all → nums is nil, not an empty slice
+	received := func(nums ...int) []int { return nums }
+	fmt.Println("  f()                        nums == nil:", received() == nil)
+	fmt.Println("  f(s[:0]...)                nums == nil:", received(s[:0]...) == nil, " cap:", cap(received(s[:0]...)))
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. Generic helpers
+// ----------------------------
+func generics() {
+	fmt.Println("🔹 2. Generic variadic helpers")
+
+	fmt.Println("  Max(3, 9, 4)                 =", v.Max(3, 9, 4))
+	fmt.Println(`  Max("go", "rust", "c")       =`, v.Max("go", "rust", "c"))
+	fmt.Println("  Max(7)                       =", v.Max(7))
+	// v.Max() ❌ compile error: not enough arguments — first is required
+
+	fmt.Printf("  Coalesce(\"\", \"\", \"fallback\") = %q\n", v.Coalesce("", "", "fallback"))
+	fmt.Println("  Coalesce(0, 0)               =", v.Coalesce(0, 0))
+
+	a, b := []int{1, 2}, []int{3}
+	all := v.Concat(a, b, nil, []int{4, 5})
+	all[0] = 100
+	fmt.Println("  Concat(a, b, nil, {4, 5}), all[0]=100 →", all, " a =", a)
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. Functional options
+// ----------------------------
+func options() {
+	fmt.Println("🔹 3. Functional options (...Option)")
+
+	tests := []struct {
+		name string
+		opts []v.Option
+	}{
+		{"defaults", nil},
+		{"WithTimeout(1s)", []v.Option{v.WithTimeout(time.Second)}},
+		{"WithRetries(0), WithTimeout(2s)", []v.Option{v.WithRetries(0), v.WithTimeout(2 * time.Second)}},
+		{"last option wins", []v.Option{v.WithRetries(1), v.WithRetries(5)}},
+	}
+	for _, tt := range tests {
+		c := v.NewClient("api", tt.opts...) // options are spread like any other slice
+		fmt.Printf("  %-34s %+v\n", tt.name, *c)
+	}
+	fmt.Println()
+}
+
+// ----------------------------
+// 4. Allocation cost
+// ----------------------------
+func benchmarks() {
+	fmt.Println("🔹 4. Cost of a call")
+
+	nums := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	x := 1000 // not a constant: boxing it into an interface allocates
+	cases := []struct {
+		name string
+		fn   func()
+	}{
+		{"Sum(1, ..., 8)           literal args", func() { v.Sum(1, 2, 3, 4, 5, 6, 7, 8) }},
+		{"Sum(nums...)             spread", func() { v.Sum(nums...) }},
+		{"SumSlice(nums)           slice param", func() { v.SumSlice(nums) }},
+		{"Keep(1, ..., 8)          args escape", func() { v.Keep(1, 2, 3, 4, 5, 6, 7, 8) }},
+		{"Keep(nums...)            spread, escapes", func() { v.Keep(nums...) }},
+		{"Log(x, x+1, \"x\")         ...any, escapes", func() { v.Log(x, x+1, "x") }},
+	}
+
+	fmt.Printf("  %-42s %10s %10s %8s\n", "call", "ns/op", "allocs/op", "B/op")
+	for _, c := range cases {
+		r := testing.Benchmark(func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.fn()
+			}
+		})
+		ns := float64(r.T.Nanoseconds()) / float64(r.N)
+		fmt.Printf("  %-42s %10.2f %10d %8d\n", c.name, ns, r.AllocsPerOp(), r.AllocedBytesPerOp())
+	}
+	fmt.Println()
+}
+
+func main() {
+	testing.Init()
+	benchtime := flag.String("benchtime", "200ms", "time spent on each benchmark")
+	flag.Parse()
+	if err := flag.Set("test.benchtime", *benchtime); err != nil {
+		fmt.Fprintln(os.Stderr, "invalid -benchtime:", err)
+		os.Exit(2)
+	}
+
+	aliasing()
+	generics()
+	options()
+	benchmarks()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) Inside the function, `nums ...int` IS a []int.
+     f()          → nums == nil
+     f(1, 2, 3)   → a fresh slice, private to this call
+     f(s...)      → s itself: writes and in-capacity appends are
+                    visible to the caller
+   Spread s[:n:n]... (full slice expression) if the callee may append.
+
+2) A variadic call is NOT expensive by itself:
+     Sum(1, ..., 8) → the compiler builds the [8]int on the caller's
+                      stack: 0 allocs, same speed as SumSlice.
+     Sum(nums...)   → just passes the slice header.
+   It allocates only when the arguments ESCAPE (Keep) — then the
+   implicit slice moves to the heap, one alloc per call.
+
+3) `...any` (fmt.Println, log.Printf) adds boxing: every escaping
+   non-constant value may need its own allocation for the interface,
+   on top of the slice (Log(x, x+1, "x") → more than Keep's one alloc;
+   constants and integers below 256 are boxed for free).
+
+4) Functional options are variadic parameters of function type:
+     NewClient("api")                                   // defaults
+     NewClient("api", WithTimeout(time.Second))         // one change
+   New options never break existing callers. 38.Functional_Options
+   builds a full configuration module on top of this.
+====================================================
+*/
@@ -0,0 +1,154 @@
+// Package variadic collects the variadic functions used by the
+// 37.Variadic_Lab lesson (20.Variadic_function, taken further).
+package variadic
+
+import (
+	"cmp"
+	"time"
+)
+
+// ----------------------------
+// 1. Aliasing: f(s...) passes the caller's slice, not a copy
+// ----------------------------
+
+// Scale multiplies every number in place. Called as Scale(2, s...) it
+// changes the caller's s; called as Scale(2, 1, 2, 3) it changes a
+// temporary slice nobody else can see.
+func Scale(factor int, nums ...int) {
+	for i := range nums {
+		nums[i] *= factor
+	}
+}
+
+// AppendOne appends 99 to nums. If nums has spare capacity (a spread
+// s[:2]...) the append writes into the CALLER's backing array.
+func AppendOne(nums ...int) []int {
+	return append(nums, 99)
+}
+
+// ScaleCopy is the safe version of Scale: it never touches its input
+func ScaleCopy(factor int, nums ...int) []int {
+	out := make([]int, len(nums))
+	for i, n := range nums {
+		out[i] = n * factor
+	}
+	return out
+}
+
+// ----------------------------
+// 2. Generic variadic helpers
+// ----------------------------
+
+// Max needs at least one value: the first parameter is not variadic,
+// so Max() is a compile error instead of a runtime panic
+func Max[T cmp.Ordered](first T, rest ...T) T {
+	m := first
+	for _, v := range rest {
+		m = max(m, v)
+	}
+	return m
+}
+
+// Coalesce returns the first value that is not the zero value
+func Coalesce[T comparable](vals ...T) T {
+	var zero T
+	for _, v := range vals {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+// Concat joins any number of slices into a new one
+func Concat[S ~[]E, E any](slices ...S) S {
+	n := 0
+	for _, s := range slices {
+		n += len(s)
+	}
+	out := make(S, 0, n)
+	for _, s := range slices {
+		out = append(out, s...)
+	}
+	return out
+}
+
+// ----------------------------
+// 3. Functional options: ...Option
+// ----------------------------
+
+// Client is configured by NewClient's options
+type Client struct {
+	Name    string
+	Timeout time.Duration
+	Retries int
+}
+
+// Option changes one setting of a Client
+type Option func(*Client)
+
+// WithTimeout sets the request timeout
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.Timeout = d }
+}
+
+// WithRetries sets how often a failed request is retried
+func WithRetries(n int) Option {
+	return func(c *Client) { c.Retries = n }
+}
+
+// NewClient applies the defaults, then every option in order: the
+// caller only mentions what differs, NewClient("api") just works
+func NewClient(name string, opts ...Option) *Client {
+	c := &Client{Name: name, Timeout: 5 * time.Second, Retries: 3}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ----------------------------
+// 4. Benchmark subjects
+// ----------------------------
+
+// Sum is variadic
+//
+//go:noinline
+func Sum(nums ...int) int {
+	total := 0
+	for _, n := range nums {
+		total += n
+	}
+	return total
+}
+
+// SumSlice is Sum with a plain slice parameter
+//
+//go:noinline
+func SumSlice(nums []int) int {
+	total := 0
+	for _, n := range nums {
+		total += n
+	}
+	return total
+}
+
+var kept []int
+
+// Keep stores its arguments, so they escape: every call with literal
+// arguments has to build the slice on the heap
+//
+//go:noinline
+func Keep(nums ...int) {
+	kept = nums
+}
+
+var logged []any
+
+// Log takes ...any like fmt.Println and keeps it, so the slice AND every
+// boxed argument escape to the heap
+//
+//go:noinline
+func Log(args ...any) {
+	logged = args
+}
@@ -0,0 +1,130 @@
+package variadic
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAliasing(t *testing.T) {
+	tests := []struct {
+		name      string
+		call      func(s []int) []int // gets a fresh []int{1, 2, 3}
+		wantS     []int
+		wantOut   []int
+		nilResult bool
+	}{
+		{"Scale(10, s...) changes s", func(s []int) []int { Scale(10, s...); return nil }, []int{10, 20, 30}, nil, true},
+		{"Scale(10, a, b, c) leaves a, b, c alone", func(s []int) []int { Scale(10, s[0], s[1], s[2]); return nil }, []int{1, 2, 3}, nil, true},
+		{"ScaleCopy returns new data", func(s []int) []int { return ScaleCopy(10, s...) }, []int{1, 2, 3}, []int{10, 20, 30}, false},
+		{"AppendOne(s[:2]...) overwrites s[2]", func(s []int) []int { return AppendOne(s[:2]...) }, []int{1, 2, 99}, []int{1, 2, 99}, false},
+		{"AppendOne(s...) with len==cap copies", func(s []int) []int {
+			out := AppendOne(s...)
+			out[0] = -1
+			return out
+		}, []int{1, 2, 3}, []int{-1, 2, 3, 99}, false},
+		{"AppendOne(s[:2:2]...) cannot reach s[2]", func(s []int) []int { return AppendOne(s[:2:2]...) }, []int{1, 2, 3}, []int{1, 2, 99}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := []int{1, 2, 3}
+			out := tt.call(s)
+			if !slices.Equal(s, tt.wantS) {
+				t.Errorf("s = %v, want %v", s, tt.wantS)
+			}
+			if (out == nil) != tt.nilResult || !slices.Equal(out, tt.wantOut) {
+				t.Errorf("out = %v, want %v", out, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestNoArguments(t *testing.T) {
+	received := func(nums ...int) []int { return nums }
+	if got := received(); got != nil {
+		t.Errorf("f() got %#v, want nil", got)
+	}
+	s := []int{1, 2, 3}
+	if got := received(s[:0]...); got == nil || cap(got) != 3 {
+		t.Errorf("f(s[:0]...) got %#v (cap %d), want s's empty slice", got, cap(got))
+	}
+}
+
+func TestMax(t *testing.T) {
+	if got := Max(3, 9, 4); got != 9 {
+		t.Errorf("Max(3, 9, 4) = %d, want 9", got)
+	}
+	if got := Max("go", "rust", "c"); got != "rust" {
+		t.Errorf(`Max("go", "rust", "c") = %q, want "rust"`, got)
+	}
+	if got := Max(7); got != 7 {
+		t.Errorf("Max(7) = %d, want 7", got)
+	}
+	// Max() does not compile: first is required
+}
+
+func TestCoalesce(t *testing.T) {
+	if got := Coalesce("", "", "fallback"); got != "fallback" {
+		t.Errorf(`Coalesce("", "", "fallback") = %q`, got)
+	}
+	if got := Coalesce(0, 0); got != 0 {
+		t.Errorf("Coalesce(0, 0) = %d, want 0", got)
+	}
+	if got := Coalesce[int](); got != 0 {
+		t.Errorf("Coalesce() = %d, want 0", got)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	a, b := []int{1, 2}, []int{3}
+	all := Concat(a, b, nil, []int{4, 5})
+	all[0] = 100
+	if !slices.Equal(all, []int{100, 2, 3, 4, 5}) || a[0] != 1 {
+		t.Errorf("Concat = %v, a = %v: want a copy", all, a)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		wantTimeout time.Duration
+		wantRetries int
+	}{
+		{"defaults", nil, 5 * time.Second, 3},
+		{"WithTimeout(1s)", []Option{WithTimeout(time.Second)}, time.Second, 3},
+		{"WithRetries(0), WithTimeout(2s)", []Option{WithRetries(0), WithTimeout(2 * time.Second)}, 2 * time.Second, 0},
+		{"last option wins", []Option{WithRetries(1), WithRetries(5)}, 5 * time.Second, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient("api", tt.opts...)
+			if c.Name != "api" || c.Timeout != tt.wantTimeout || c.Retries != tt.wantRetries {
+				t.Errorf("got %+v, want Timeout=%v Retries=%d", *c, tt.wantTimeout, tt.wantRetries)
+			}
+		})
+	}
+}
+
+func TestAllocs(t *testing.T) {
+	nums := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	tests := []struct {
+		name string
+		fn   func()
+		want float64
+	}{
+		{"Sum literal args: slice on the caller's stack", func() { Sum(1, 2, 3, 4, 5, 6, 7, 8) }, 0},
+		{"Sum(nums...)", func() { Sum(nums...) }, 0},
+		{"SumSlice(nums)", func() { SumSlice(nums) }, 0},
+		{"Keep literal args: slice escapes", func() { Keep(1, 2, 3, 4, 5, 6, 7, 8) }, 1},
+		{"Keep(nums...): nothing new to allocate", func() { Keep(nums...) }, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testing.AllocsPerRun(100, tt.fn); got != tt.want {
+				t.Errorf("%v allocs per call, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go_projects/38.Functional_Options/server"
+)
+
+/*
+====================================================
+🔹 FUNCTIONAL OPTIONS: FUNCTION TYPES AS CONFIGURATION
+====================================================
+
+6.Function_Types passes behaviour around:
+    operate(10, 20, multiply)            // op func(int, int) int
+    process("Sadik", func(n string) {})  // callback func(string)
+
+Functional options use the same idea for configuration:
+
+    type Option func(*Server) error
+
+    srv, err := server.New(
+        server.WithPort(9000),
+        server.WithTLS("cert.pem", "key.pem"),
+        server.WithLogger(func(line string) { fmt.Println(line) }),
+    )
+
+   - every option is a closure that edits the Server being built,
+   - every option validates its own argument (returns an error),
+   - New starts from defaults, applies options IN ORDER, then checks
+     rules that involve several options (conflicts),
+   - options compose: server.Production(...) is just Options(a, b, c).
+
+The same Server is also built with a config struct and with a builder,
+to compare the three styles. Every case is a table test in
+server/server_test.go.
+
+Run it:
+    go run ./38.Functional_Options
+    go test ./38.Functional_Options/...
+====================================================
+*/
+
+// show prints what New (or another constructor) returned
+func show(name string, srv *server.Server, err error) {
+	if err != nil {
+		fmt.Printf("  %-34s err=%v\n", name, err)
+		return
+	}
+	fmt.Printf("  %-34s addr=%s\n", name, srv.Addr())
+}
+
+// ----------------------------
+// 1. Defaults, validation and conflicts
+// ----------------------------
+func optionTable() {
+	fmt.Println("🔹 1. New(opts...): defaults, validation, conflicts")
+
+	tests := []struct {
+		name string
+		opts []server.Option
+	}{
+		{"no options → defaults", nil},
+		{"WithPort(9000)", []server.Option{server.WithPort(9000)}},
+		{"WithTLS moves default port", []server.Option{server.WithTLS("c.pem", "k.pem")}},
+		{"WithPort wins over WithTLS's port", []server.Option{server.WithPort(9443), server.WithTLS("c.pem", "k.pem")}},
+
+		// each option validates its own argument
+		{"WithPort(0)", []server.Option{server.WithPort(0)}},
+		{"WithTLS without key", []server.Option{server.WithTLS("c.pem", "")}},
+		{"WithLogger(nil)", []server.Option{server.WithLogger(nil)}},
+
+		// conflicts: detected whatever the order
+		{"WithTLS + WithPlainHTTP", []server.Option{server.WithTLS("c.pem", "k.pem"), server.WithPlainHTTP()}},
+		{"WithPlainHTTP + WithTLS", []server.Option{server.WithPlainHTTP(), server.WithTLS("c.pem", "k.pem")}},
+		{"write timeout < read timeout", []server.Option{server.WithTimeouts(10*time.Second, time.Second)}},
+
+		// composition: a preset, then an override
+		{"Production(...)", []server.Option{server.Production("c.pem", "k.pem")}},
+		{"Production(...) + WithPort(443)", []server.Option{server.Production("c.pem", "k.pem"), server.WithPort(443)}},
+	}
+
+	for _, tt := range tests {
+		srv, err := server.New(tt.opts...)
+		show(tt.name, srv, err)
+	}
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. The logger option is a callback
+// ----------------------------
+func callback() {
+	fmt.Println("🔹 2. WithLogger: a callback, like process(name, callback)")
+
+	var lines []string
+	srv, err := server.New(
+		server.WithPort(9000),
+		server.WithLogger(func(line string) { lines = append(lines, line) }),
+	)
+	if err != nil {
+		fmt.Println("  New:", err)
+		return
+	}
+	srv.Start()
+	fmt.Printf("  Start() reported through the callback: %q\n", lines)
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. Three ways to build the same server
+// ----------------------------
+func compare() {
+	fmt.Println("🔹 3. Options vs config struct vs builder")
+
+	withOptions, err1 := server.New(
+		server.WithPort(9443),
+		server.WithTLS("c.pem", "k.pem"),
+		server.WithTimeouts(2*time.Second, 4*time.Second),
+	)
+	withStruct, err2 := server.NewFromConfig(server.Config{
+		Port:         9443,
+		TLS:          true,
+		CertFile:     "c.pem",
+		KeyFile:      "k.pem",
+		ReadTimeout:  2 * time.Second,
+		WriteTimeout: 4 * time.Second,
+	})
+	withBuilder, err3 := server.NewBuilder().
+		Port(9443).
+		TLS("c.pem", "k.pem").
+		Timeouts(2*time.Second, 4*time.Second).
+		Build()
+
+	if err := errors.Join(err1, err2, err3); err != nil {
+		fmt.Println("  build:", err)
+		return
+	}
+	fmt.Printf("  options: %+v\n", withOptions.Config())
+	fmt.Printf("  struct:  %+v\n", withStruct.Config())
+	fmt.Printf("  builder: %+v\n", withBuilder.Config())
+	fmt.Println("  same Config:", withOptions.Config() == withStruct.Config() && withStruct.Config() == withBuilder.Config())
+
+	// where the config struct falls short: 0 means "default", so a real
+	// zero cannot be requested, and an invalid 0 is silently ignored
+	srv, err := server.NewFromConfig(server.Config{MaxConns: 0})
+	if err == nil {
+		fmt.Println("  Config{MaxConns: 0}  → MaxConns =", srv.Config().MaxConns, "(the default, silently)")
+	}
+	_, err = server.New(server.WithMaxConns(0))
+	fmt.Println("  WithMaxConns(0)      → err =", err)
+	fmt.Println()
+}
+
+func main() {
+	optionTable()
+	callback()
+	compare()
+}
+
+/*
+====================================================
+🔹 OPTIONS vs CONFIG STRUCT vs BUILDER
+====================================================
+
+                        options          config struct      builder
+   defaults             in New           zero = default     in Build
+   unset vs zero        not passed vs    ❌ both 0          not called vs
+                        passed                              called
+   validation           per option       in one place       in Build
+   conflicts            New (any order)  in one place       in Build
+   presets / compose    Options(a, b)    copy a struct      helper funcs
+   add a setting        new func only    new field          new method
+   discoverability      godoc list       ✅ all fields      ✅ methods
+                        of With*         in one place
+
+Rules of thumb:
+   - Few settings, all required → plain parameters.
+   - Many settings, mostly plain data (read from a file) → config struct.
+   - Public API that must grow without breaking callers → options.
+   - Builders are rare in Go: a method chain cannot return an error
+     at each step.
+====================================================
+*/
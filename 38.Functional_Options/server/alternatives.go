@@ -0,0 +1,73 @@
+package server
+
+import "time"
+
+// ----------------------------
+// Alternative 1: a config struct
+// ----------------------------
+
+// NewFromConfig builds a Server from a Config. Zero fields mean "use the
+// default" — so a zero value can never be asked for on purpose.
+func NewFromConfig(c Config) (*Server, error) {
+	var opts []Option
+	if c.Host != "" {
+		opts = append(opts, WithHost(c.Host))
+	}
+	if c.Port != 0 {
+		opts = append(opts, WithPort(c.Port))
+	}
+	if c.ReadTimeout != 0 || c.WriteTimeout != 0 {
+		read, write := c.ReadTimeout, c.WriteTimeout
+		if read == 0 {
+			read = Defaults.ReadTimeout
+		}
+		if write == 0 {
+			write = Defaults.WriteTimeout
+		}
+		opts = append(opts, WithTimeouts(read, write))
+	}
+	if c.MaxConns != 0 {
+		opts = append(opts, WithMaxConns(c.MaxConns))
+	}
+	if c.TLS {
+		opts = append(opts, WithTLS(c.CertFile, c.KeyFile))
+	}
+	return New(opts...)
+}
+
+// ----------------------------
+// Alternative 2: a builder
+// ----------------------------
+
+// Builder collects settings through chained method calls. A method
+// cannot return an error without breaking the chain, so validation
+// waits until Build.
+type Builder struct {
+	opts []Option
+}
+
+// NewBuilder starts an empty builder
+func NewBuilder() *Builder { return &Builder{} }
+
+// Port sets the port
+func (b *Builder) Port(port int) *Builder {
+	b.opts = append(b.opts, WithPort(port))
+	return b
+}
+
+// Timeouts sets both timeouts
+func (b *Builder) Timeouts(read, write time.Duration) *Builder {
+	b.opts = append(b.opts, WithTimeouts(read, write))
+	return b
+}
+
+// TLS turns TLS on
+func (b *Builder) TLS(certFile, keyFile string) *Builder {
+	b.opts = append(b.opts, WithTLS(certFile, keyFile))
+	return b
+}
+
+// Build creates the Server
+func (b *Builder) Build() (*Server, error) {
+	return New(b.opts...)
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option configures a Server. It returns an error, so every option can
+// validate its own argument.
+type Option func(*Server) error
+
+// WithHost sets the interface to listen on
+func WithHost(host string) Option {
+	return func(s *Server) error {
+		if host == "" {
+			return fmt.Errorf("WithHost(%q): empty host: %w", host, ErrInvalid)
+		}
+		s.cfg.Host = host
+		return nil
+	}
+}
+
+// WithPort sets the TCP port
+func WithPort(port int) Option {
+	return func(s *Server) error {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("WithPort(%d): out of range 1-65535: %w", port, ErrInvalid)
+		}
+		s.cfg.Port = port
+		s.portSet = true
+		return nil
+	}
+}
+
+// WithTimeouts sets the read and write timeouts
+func WithTimeouts(read, write time.Duration) Option {
+	return func(s *Server) error {
+		if read <= 0 || write <= 0 {
+			return fmt.Errorf("WithTimeouts(%v, %v): must be positive: %w", read, write, ErrInvalid)
+		}
+		s.cfg.ReadTimeout, s.cfg.WriteTimeout = read, write
+		return nil
+	}
+}
+
+// WithMaxConns limits concurrent connections
+func WithMaxConns(n int) Option {
+	return func(s *Server) error {
+		if n < 1 {
+			return fmt.Errorf("WithMaxConns(%d): must be at least 1: %w", n, ErrInvalid)
+		}
+		s.cfg.MaxConns = n
+		return nil
+	}
+}
+
+// WithTLS turns TLS on; the port moves to 8443 unless WithPort sets one
+func WithTLS(certFile, keyFile string) Option {
+	return func(s *Server) error {
+		if certFile == "" || keyFile == "" {
+			return fmt.Errorf("WithTLS: cert and key are required: %w", ErrInvalid)
+		}
+		if s.tlsMode == tlsOff {
+			return fmt.Errorf("WithTLS after WithPlainHTTP: %w", ErrConflict)
+		}
+		s.tlsMode = tlsOn
+		s.cfg.TLS, s.cfg.CertFile, s.cfg.KeyFile = true, certFile, keyFile
+		if !s.portSet {
+			s.cfg.Port = 8443
+		}
+		return nil
+	}
+}
+
+// WithPlainHTTP states explicitly that TLS must stay off
+func WithPlainHTTP() Option {
+	return func(s *Server) error {
+		if s.tlsMode == tlsOn {
+			return fmt.Errorf("WithPlainHTTP after WithTLS: %w", ErrConflict)
+		}
+		s.tlsMode = tlsOff
+		return nil
+	}
+}
+
+// WithLogger sets the callback that receives log lines
+func WithLogger(log func(string)) Option {
+	return func(s *Server) error {
+		if log == nil {
+			return fmt.Errorf("WithLogger(nil): %w", ErrInvalid)
+		}
+		s.log = log
+		return nil
+	}
+}
+
+// ----------------------------
+// Composition
+// ----------------------------
+
+// Options combines several options into one; they run in order
+func Options(opts ...Option) Option {
+	return func(s *Server) error {
+		for _, opt := range opts {
+			if err := opt(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Production is a preset built from other options. Later options
+// passed to New still override it.
+func Production(certFile, keyFile string) Option {
+	return Options(
+		WithHost("0.0.0.0"),
+		WithTLS(certFile, keyFile),
+		WithTimeouts(10*time.Second, 30*time.Second),
+		WithMaxConns(10_000),
+	)
+}
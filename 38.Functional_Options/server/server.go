@@ -0,0 +1,99 @@
+// Package server configures a (pretend) HTTP server with functional
+// options: 6.Function_Types' `op func(int, int) int` and
+// `callback func(string)`, applied to configuration.
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalid is wrapped by every option that rejects its argument
+	ErrInvalid = errors.New("invalid option")
+	// ErrConflict is wrapped when two options cannot be used together
+	ErrConflict = errors.New("conflicting options")
+)
+
+// Config is every setting of a Server. It is also the "config struct"
+// alternative to options (see NewFromConfig).
+type Config struct {
+	Host         string
+	Port         int
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	MaxConns     int
+	TLS          bool
+	CertFile     string
+	KeyFile      string
+}
+
+// Defaults is the configuration New starts from
+var Defaults = Config{
+	Host:         "localhost",
+	Port:         8080,
+	ReadTimeout:  5 * time.Second,
+	WriteTimeout: 10 * time.Second,
+	MaxConns:     100,
+}
+
+// tlsMode remembers whether TLS was chosen explicitly, so WithTLS and
+// WithPlainHTTP conflict in either order
+type tlsMode int
+
+const (
+	tlsUnset tlsMode = iota
+	tlsOn
+	tlsOff
+)
+
+// Server is configured once, by New, and never changes afterwards
+type Server struct {
+	cfg     Config
+	tlsMode tlsMode
+	portSet bool
+	log     func(string) // a callback, like process(name, callback)
+}
+
+// New applies Defaults, then opts in order, then checks the combination.
+// The first failing option stops it; the error names the option.
+func New(opts ...Option) (*Server, error) {
+	s := &Server{cfg: Defaults, log: func(string) {}}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, fmt.Errorf("server: %w", err)
+		}
+	}
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("server: %w", err)
+	}
+	return s, nil
+}
+
+// validate checks rules that involve more than one option
+func (s *Server) validate() error {
+	if s.cfg.TLS && s.portSet && s.cfg.Port == 80 {
+		return fmt.Errorf("TLS on port 80: %w", ErrConflict)
+	}
+	if s.cfg.WriteTimeout < s.cfg.ReadTimeout {
+		return fmt.Errorf("write timeout %v shorter than read timeout %v: %w",
+			s.cfg.WriteTimeout, s.cfg.ReadTimeout, ErrConflict)
+	}
+	return nil
+}
+
+// Config returns a copy of the settings
+func (s *Server) Config() Config { return s.cfg }
+
+// Addr is host:port
+func (s *Server) Addr() string { return fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port) }
+
+// Start pretends to start listening and reports it to the logger
+func (s *Server) Start() {
+	scheme := "http"
+	if s.cfg.TLS {
+		scheme = "https"
+	}
+	s.log(fmt.Sprintf("listening on %s://%s (max %d conns)", scheme, s.Addr(), s.cfg.MaxConns))
+}
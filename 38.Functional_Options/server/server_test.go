@@ -0,0 +1,121 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantAddr string
+		wantErr  error // nil, ErrInvalid or ErrConflict
+	}{
+		{name: "no options → defaults", wantAddr: "localhost:8080"},
+		{name: "WithPort(9000)", opts: []Option{WithPort(9000)}, wantAddr: "localhost:9000"},
+		{name: "WithTLS moves default port", opts: []Option{WithTLS("c.pem", "k.pem")}, wantAddr: "localhost:8443"},
+		{name: "WithPort wins over WithTLS's port",
+			opts:     []Option{WithPort(9443), WithTLS("c.pem", "k.pem")},
+			wantAddr: "localhost:9443"},
+
+		// each option validates its own argument
+		{name: "WithPort(0)", opts: []Option{WithPort(0)}, wantErr: ErrInvalid},
+		{name: "WithHost(\"\")", opts: []Option{WithHost("")}, wantErr: ErrInvalid},
+		{name: "WithMaxConns(-1)", opts: []Option{WithMaxConns(-1)}, wantErr: ErrInvalid},
+		{name: "WithMaxConns(0)", opts: []Option{WithMaxConns(0)}, wantErr: ErrInvalid},
+		{name: "WithTLS without key", opts: []Option{WithTLS("c.pem", "")}, wantErr: ErrInvalid},
+		{name: "WithLogger(nil)", opts: []Option{WithLogger(nil)}, wantErr: ErrInvalid},
+
+		// conflicts: detected whatever the order
+		{name: "WithTLS + WithPlainHTTP",
+			opts: []Option{WithTLS("c.pem", "k.pem"), WithPlainHTTP()}, wantErr: ErrConflict},
+		{name: "WithPlainHTTP + WithTLS",
+			opts: []Option{WithPlainHTTP(), WithTLS("c.pem", "k.pem")}, wantErr: ErrConflict},
+		{name: "WithTLS on WithPort(80)",
+			opts: []Option{WithTLS("c.pem", "k.pem"), WithPort(80)}, wantErr: ErrConflict},
+		{name: "write timeout < read timeout",
+			opts: []Option{WithTimeouts(10*time.Second, time.Second)}, wantErr: ErrConflict},
+
+		// composition: a preset, then an override
+		{name: "Production(...)", opts: []Option{Production("c.pem", "k.pem")}, wantAddr: "0.0.0.0:8443"},
+		{name: "Production(...) + WithPort(443)",
+			opts:     []Option{Production("c.pem", "k.pem"), WithPort(443)},
+			wantAddr: "0.0.0.0:443"},
+		{name: "Production(\"\", \"\")", opts: []Option{Production("", "")}, wantErr: ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := New(tt.opts...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if srv.Addr() != tt.wantAddr {
+				t.Errorf("Addr() = %q, want %q", srv.Addr(), tt.wantAddr)
+			}
+		})
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	var lines []string
+	srv, err := New(
+		WithPort(9000),
+		WithLogger(func(line string) { lines = append(lines, line) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Start()
+	if len(lines) != 1 {
+		t.Errorf("Start() logged %q, want one line through the callback", lines)
+	}
+}
+
+func TestThreeStylesAgree(t *testing.T) {
+	withOptions, err1 := New(
+		WithPort(9443),
+		WithTLS("c.pem", "k.pem"),
+		WithTimeouts(2*time.Second, 4*time.Second),
+	)
+	withStruct, err2 := NewFromConfig(Config{
+		Port:         9443,
+		TLS:          true,
+		CertFile:     "c.pem",
+		KeyFile:      "k.pem",
+		ReadTimeout:  2 * time.Second,
+		WriteTimeout: 4 * time.Second,
+	})
+	withBuilder, err3 := NewBuilder().
+		Port(9443).
+		TLS("c.pem", "k.pem").
+		Timeouts(2*time.Second, 4*time.Second).
+		Build()
+	if err := errors.Join(err1, err2, err3); err != nil {
+		t.Fatal(err)
+	}
+
+	if withOptions.Config() != withStruct.Config() || withStruct.Config() != withBuilder.Config() {
+		t.Errorf("configs differ:\noptions %+v\nstruct  %+v\nbuilder %+v",
+			withOptions.Config(), withStruct.Config(), withBuilder.Config())
+	}
+}
+
+func TestConfigZeroMeansDefault(t *testing.T) {
+	// the config struct cannot tell "not set" from 0
+	srv, err := NewFromConfig(Config{MaxConns: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Config().MaxConns; got != Defaults.MaxConns {
+		t.Errorf("Config{MaxConns: 0} → MaxConns=%d, want the default %d", got, Defaults.MaxConns)
+	}
+}
@@ -0,0 +1,180 @@
+// Package chain composes handlers with decorators: functions that take a
+// handler and return a new one wrapped around it. It is 11.FOF_HOF's
+// processOperation (function as parameter) and call() (function as
+// return value) in one signature: func(Handler) Handler.
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Handler turns an input into an output. In and Out are generic, so the
+// same middleware works for func(pair) int, func(string) User, ...
+type Handler[In, Out any] func(ctx context.Context, in In) (Out, error)
+
+// Middleware wraps a Handler and returns the wrapped Handler
+type Middleware[In, Out any] func(Handler[In, Out]) Handler[In, Out]
+
+// Chain combines middleware into one. The FIRST one is the OUTERMOST:
+//
+//	Chain(a, b, c)(h) == a(b(c(h)))
+//
+// so a call runs a → b → c → h and returns through c → b → a.
+func Chain[In, Out any](mws ...Middleware[In, Out]) Middleware[In, Out] {
+	return func(h Handler[In, Out]) Handler[In, Out] {
+		for i := len(mws) - 1; i >= 0; i-- {
+			h = mws[i](h)
+		}
+		return h
+	}
+}
+
+// Apply wraps h with mws; Apply(h, a, b) == Chain(a, b)(h)
+func Apply[In, Out any](h Handler[In, Out], mws ...Middleware[In, Out]) Handler[In, Out] {
+	return Chain(mws...)(h)
+}
+
+// ----------------------------
+// Logging and timing
+// ----------------------------
+
+// Logging reports every call and its result through logf
+func Logging[In, Out any](name string, logf func(format string, args ...any)) Middleware[In, Out] {
+	return func(next Handler[In, Out]) Handler[In, Out] {
+		return func(ctx context.Context, in In) (Out, error) {
+			logf("%s(%v) called", name, in)
+			out, err := next(ctx, in)
+			if err != nil {
+				logf("%s(%v) failed: %v", name, in, err)
+			} else {
+				logf("%s(%v) = %v", name, in, out)
+			}
+			return out, err
+		}
+	}
+}
+
+// Timing reports how long the wrapped handler took, errors included
+func Timing[In, Out any](report func(time.Duration)) Middleware[In, Out] {
+	return func(next Handler[In, Out]) Handler[In, Out] {
+		return func(ctx context.Context, in In) (Out, error) {
+			start := time.Now()
+			defer func() { report(time.Since(start)) }()
+			return next(ctx, in)
+		}
+	}
+}
+
+// ----------------------------
+// Retry with backoff
+// ----------------------------
+
+// Backoff returns how long to wait before retry number n (1, 2, ...)
+type Backoff func(n int) time.Duration
+
+// Exponential doubles the wait on every retry, up to max:
+// base, 2·base, 4·base, ... max
+func Exponential(base, max time.Duration) Backoff {
+	return func(n int) time.Duration {
+		d := base
+		for i := 1; i < n && d < max; i++ {
+			d *= 2
+		}
+		return min(d, max)
+	}
+}
+
+// Retry calls the handler up to attempts times while it fails, waiting
+// backoff(n) before retry n. It gives up early when ctx is done; the
+// last error and the context error are both returned. The handler is
+// always called at least once: attempts below 1 count as 1.
+func Retry[In, Out any](attempts int, backoff Backoff) Middleware[In, Out] {
+	attempts = max(attempts, 1)
+	return func(next Handler[In, Out]) Handler[In, Out] {
+		return func(ctx context.Context, in In) (Out, error) {
+			var out Out
+			var err error
+			for n := 0; n < attempts; n++ {
+				if n > 0 {
+					timer := time.NewTimer(backoff(n))
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						return out, errors.Join(err, ctx.Err())
+					case <-timer.C:
+					}
+				}
+				if out, err = next(ctx, in); err == nil {
+					return out, nil
+				}
+			}
+			return out, fmt.Errorf("after %d attempts: %w", attempts, err)
+		}
+	}
+}
+
+// ----------------------------
+// Memoization
+// ----------------------------
+
+// Memoize caches successful results per input. Errors are not cached,
+// so a failing call is tried again next time. In must be comparable: it
+// is the map key.
+func Memoize[In comparable, Out any]() Middleware[In, Out] {
+	return func(next Handler[In, Out]) Handler[In, Out] {
+		var mu sync.Mutex
+		cache := map[In]Out{}
+		return func(ctx context.Context, in In) (Out, error) {
+			mu.Lock()
+			out, ok := cache[in]
+			mu.Unlock()
+			if ok {
+				return out, nil
+			}
+			out, err := next(ctx, in)
+			if err == nil {
+				mu.Lock()
+				cache[in] = out
+				mu.Unlock()
+			}
+			return out, err
+		}
+	}
+}
+
+// ----------------------------
+// Tracing and failures, for experiments
+// ----------------------------
+
+// Trace appends "name→" to trace when a call enters and "←name" when it
+// leaves, which shows the order of the layers. It is not safe for
+// concurrent calls.
+func Trace[In, Out any](name string, trace *[]string) Middleware[In, Out] {
+	return func(next Handler[In, Out]) Handler[In, Out] {
+		return func(ctx context.Context, in In) (Out, error) {
+			*trace = append(*trace, name+"→")
+			defer func() { *trace = append(*trace, "←"+name) }()
+			return next(ctx, in)
+		}
+	}
+}
+
+// FailFirst fails the first n calls without calling the handler, like a
+// dependency that is down for a moment. calls counts every call, failed
+// or not. It is not safe for concurrent calls.
+func FailFirst[In, Out any](n int, calls *int) Middleware[In, Out] {
+	return func(next Handler[In, Out]) Handler[In, Out] {
+		return func(ctx context.Context, in In) (Out, error) {
+			*calls++
+			if *calls <= n {
+				var zero Out
+				return zero, fmt.Errorf("temporary failure %d", *calls)
+			}
+			return next(ctx, in)
+		}
+	}
+}
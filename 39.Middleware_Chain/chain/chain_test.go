@@ -0,0 +1,172 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pair is the input of 11.FOF_HOF's add and multiply
+type pair struct{ A, B int }
+
+func add(_ context.Context, p pair) (int, error)      { return p.A + p.B, nil }
+func multiply(_ context.Context, p pair) (int, error) { return p.A * p.B, nil }
+
+func TestChainOrder(t *testing.T) {
+	want := []string{"a→", "b→", "c→", "←c", "←b", "←a"}
+
+	var trace []string
+	h := Apply(add,
+		Trace[pair, int]("a", &trace), Trace[pair, int]("b", &trace), Trace[pair, int]("c", &trace))
+	if sum, _ := h(context.Background(), pair{2, 5}); sum != 7 || !slices.Equal(trace, want) {
+		t.Errorf("Apply(add, a, b, c): sum=%d trace=%v, want 7 %v", sum, trace, want)
+	}
+
+	// Chain is itself a middleware, so chains nest
+	trace = nil
+	h = Apply(multiply, Trace[pair, int]("a", &trace), Chain(Trace[pair, int]("b", &trace), Trace[pair, int]("c", &trace)))
+	if product, _ := h(context.Background(), pair{3, 7}); product != 21 || !slices.Equal(trace, want) {
+		t.Errorf("Chain(a, Chain(b, c)): product=%d trace=%v, want 21 %v", product, trace, want)
+	}
+}
+
+func TestLoggingTiming(t *testing.T) {
+	var lines []string
+	logf := func(format string, args ...any) { lines = append(lines, fmt.Sprintf(format, args...)) }
+	var took []time.Duration
+	report := func(d time.Duration) { took = append(took, d) }
+
+	ops := map[string]Handler[pair, int]{"add": add, "multiply": multiply}
+	for _, name := range []string{"add", "multiply"} {
+		h := Apply(ops[name], Logging[pair, int](name, logf), Timing[pair, int](report))
+		h(context.Background(), pair{3, 7})
+	}
+
+	want := []string{"add({3 7}) called", "add({3 7}) = 10", "multiply({3 7}) called", "multiply({3 7}) = 21"}
+	if !slices.Equal(lines, want) {
+		t.Errorf("log = %q, want %q", lines, want)
+	}
+	if len(took) != 2 {
+		t.Errorf("Timing reported %v, want once per call", took)
+	}
+}
+
+func TestExponential(t *testing.T) {
+	backoff := Exponential(time.Millisecond, 4*time.Millisecond)
+	var waits []time.Duration
+	for n := 1; n <= 4; n++ {
+		waits = append(waits, backoff(n))
+	}
+	if want := []time.Duration{1e6, 2e6, 4e6, 4e6}; !slices.Equal(waits, want) {
+		t.Errorf("waits = %v, want %v", waits, want)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	backoff := Exponential(time.Millisecond, 4*time.Millisecond)
+	tests := []struct {
+		name      string
+		failures  int
+		attempts  int
+		wantErr   string // "" = success
+		wantCalls int
+	}{
+		{"fails twice, 3 attempts", 2, 3, "", 3},
+		{"fails twice, 2 attempts", 2, 2, "after 2 attempts: temporary failure 2", 2},
+		{"never fails, 5 attempts", 0, 5, "", 1},
+		{"0 attempts counts as 1", 0, 0, "", 1},
+		{"-1 attempts counts as 1", 1, -1, "after 1 attempts: temporary failure 1", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			h := Apply(add, Retry[pair, int](tt.attempts, backoff), FailFirst[pair, int](tt.failures, &calls))
+			sum, err := h(context.Background(), pair{2, 3})
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			switch {
+			case tt.wantErr == "" && (err != nil || sum != 5):
+				t.Errorf("sum=%d err=%v, want 5 <nil>", sum, err)
+			case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryStopsOnDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	calls := 0
+	h := Apply(add, Retry[pair, int](100, Exponential(time.Millisecond, 2*time.Millisecond)), FailFirst[pair, int](100, &calls))
+	_, err := h(ctx, pair{1, 1})
+	if !errors.Is(err, context.DeadlineExceeded) || calls >= 100 {
+		t.Errorf("err=%v calls=%d, want DeadlineExceeded well before 100 calls", err, calls)
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	counted := func(ctx context.Context, p pair) (int, error) {
+		calls++
+		return multiply(ctx, p)
+	}
+	h := Apply(counted, Memoize[pair, int]())
+	for _, p := range []pair{{3, 7}, {3, 7}, {2, 2}, {3, 7}} {
+		h(context.Background(), p)
+	}
+	if calls != 2 {
+		t.Errorf("4 calls with 2 distinct inputs made %d real calls, want 2", calls)
+	}
+
+	calls = 0
+	h = Apply(add, Memoize[pair, int](), FailFirst[pair, int](1, &calls))
+	_, err1 := h(context.Background(), pair{1, 2})
+	_, err2 := h(context.Background(), pair{1, 2})
+	if err1 == nil || err2 != nil || calls != 2 {
+		t.Errorf("err1=%v err2=%v calls=%d: errors must not be cached", err1, err2, calls)
+	}
+}
+
+func TestTimingPosition(t *testing.T) {
+	// Timing OUTSIDE Retry measures the whole thing, backoff included;
+	// Timing INSIDE Retry measures every single attempt
+	var outer, inner []time.Duration
+	calls := 0
+	h := Apply(add,
+		Timing[pair, int](func(d time.Duration) { outer = append(outer, d) }),
+		Retry[pair, int](3, Exponential(2*time.Millisecond, 2*time.Millisecond)),
+		Timing[pair, int](func(d time.Duration) { inner = append(inner, d) }),
+		FailFirst[pair, int](2, &calls),
+	)
+	h(context.Background(), pair{1, 2})
+	if len(outer) != 1 || outer[0] < 4*time.Millisecond {
+		t.Errorf("outer Timing = %v, want 1 report ≥ 4ms", outer)
+	}
+	if len(inner) != 3 {
+		t.Errorf("inner Timing = %v, want 3 reports", inner)
+	}
+}
+
+func TestOtherTypes(t *testing.T) {
+	type user struct{ Name string }
+	lookups := 0
+	lookup := func(_ context.Context, id string) (user, error) {
+		lookups++
+		return user{Name: strings.ToUpper(id)}, nil
+	}
+
+	var lines []string
+	logf := func(format string, args ...any) { lines = append(lines, fmt.Sprintf(format, args...)) }
+	h := Apply(lookup, Logging[string, user]("lookup", logf), Memoize[string, user]())
+	h(context.Background(), "sadik")
+	u, _ := h(context.Background(), "sadik")
+	if u.Name != "SADIK" || lookups != 1 || len(lines) != 4 {
+		t.Errorf("user=%+v lookups=%d log=%q", u, lookups, lines)
+	}
+}
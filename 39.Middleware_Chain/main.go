@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go_projects/39.Middleware_Chain/chain"
+)
+
+/*
+====================================================
+🔹 MIDDLEWARE: HIGHER ORDER FUNCTIONS, STACKED
+====================================================
+
+11.FOF_HOF has both halves of a middleware:
+    processOperation(3, 7, add)   → a function that TAKES a function
+    sum := call()                 → a function that RETURNS a function
+
+A middleware does both at once:
+
+    type Middleware func(Handler) Handler
+
+    func Logging(next Handler) Handler {
+        return func(in) out {
+            log("before")
+            out := next(in)     // call the wrapped function
+            log("after")
+            return out
+        }
+    }
+
+Stack them and every call passes through all layers:
+
+    h := chain.Apply(add, Logging, Timing, Retry, Memoize)
+
+          Logging → Timing → Retry → Memoize → add
+          Logging ← Timing ← Retry ← Memoize ← add
+
+The chain package is generic: Handler[In, Out] works for any input and
+output type, so one Logging serves add(pair) int and lookup(string) User.
+The expected results are tests in chain/chain_test.go.
+
+Run it:
+    go run ./39.Middleware_Chain
+    go test ./39.Middleware_Chain/...
+====================================================
+*/
+
+// pair is the input of 11.FOF_HOF's add and multiply
+type pair struct{ A, B int }
+
+// add and multiply are 11.FOF_HOF's functions, returning their result
+// instead of printing it, in the Handler shape
+func add(_ context.Context, p pair) (int, error)      { return p.A + p.B, nil }
+func multiply(_ context.Context, p pair) (int, error) { return p.A * p.B, nil }
+
+// ----------------------------
+// 1. Wrapping order
+// ----------------------------
+func order() {
+	fmt.Println("🔹 1. Chain(a, b, c)(h) == a(b(c(h)))")
+
+	var trace []string
+	h := chain.Apply(add,
+		chain.Trace[pair, int]("a", &trace), chain.Trace[pair, int]("b", &trace), chain.Trace[pair, int]("c", &trace))
+	sum, _ := h(context.Background(), pair{2, 5})
+	fmt.Printf("  Apply(add, a, b, c)          %s   sum=%d\n", strings.Join(trace, " "), sum)
+
+	// Chain is itself a middleware, so chains nest
+	trace = nil
+	inner := chain.Chain(chain.Trace[pair, int]("b", &trace), chain.Trace[pair, int]("c", &trace))
+	h = chain.Apply(multiply, chain.Trace[pair, int]("a", &trace), inner)
+	product, _ := h(context.Background(), pair{3, 7})
+	fmt.Printf("  Apply(mul, a, Chain(b, c))   %s   product=%d\n", strings.Join(trace, " "), product)
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. Logging and timing
+// ----------------------------
+func loggingTiming() {
+	fmt.Println("🔹 2. Logging and Timing wrap add and multiply")
+
+	var lines []string
+	logf := func(format string, args ...any) { lines = append(lines, fmt.Sprintf(format, args...)) }
+	var took []time.Duration
+	report := func(d time.Duration) { took = append(took, d) }
+
+	ops := map[string]chain.Handler[pair, int]{"add": add, "multiply": multiply}
+	for _, name := range []string{"add", "multiply"} {
+		h := chain.Apply(ops[name], chain.Logging[pair, int](name, logf), chain.Timing[pair, int](report))
+		h(context.Background(), pair{3, 7})
+	}
+	for _, line := range lines {
+		fmt.Println("  log:", line)
+	}
+	fmt.Println("  timing:", took)
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. Retry with backoff
+// ----------------------------
+func retry() {
+	fmt.Println("🔹 3. Retry with exponential backoff")
+
+	backoff := chain.Exponential(time.Millisecond, 4*time.Millisecond)
+	var waits []time.Duration
+	for n := 1; n <= 4; n++ {
+		waits = append(waits, backoff(n))
+	}
+	fmt.Println("  Exponential(1ms, 4ms) waits:", waits)
+
+	tests := []struct {
+		name     string
+		failures int
+		attempts int
+	}{
+		{"fails twice, 3 attempts", 2, 3},
+		{"fails twice, 2 attempts", 2, 2},
+		{"never fails, 5 attempts", 0, 5},
+		{"fails once, 0 attempts (→ 1)", 1, 0},
+	}
+	for _, tt := range tests {
+		calls := 0
+		h := chain.Apply(add, chain.Retry[pair, int](tt.attempts, backoff), chain.FailFirst[pair, int](tt.failures, &calls))
+		sum, err := h(context.Background(), pair{2, 3})
+		fmt.Printf("  %-30s calls=%d sum=%d err=%v\n", tt.name, calls, sum, err)
+	}
+
+	// a cancelled context stops the retries during the backoff wait
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	calls := 0
+	slow := chain.Apply(add, chain.Retry[pair, int](100, chain.Exponential(time.Millisecond, 2*time.Millisecond)), chain.FailFirst[pair, int](100, &calls))
+	_, err := slow(ctx, pair{1, 1})
+	fmt.Printf("  5ms deadline, 100 attempts → stopped after %d calls (deadline: %v)\n",
+		calls, errors.Is(err, context.DeadlineExceeded))
+	fmt.Println()
+}
+
+// ----------------------------
+// 4. Memoization, and why order matters
+// ----------------------------
+func memoize() {
+	fmt.Println("🔹 4. Memoize, and the order of the layers")
+
+	calls := 0
+	counted := func(ctx context.Context, p pair) (int, error) {
+		calls++
+		return multiply(ctx, p)
+	}
+	h := chain.Apply(counted, chain.Memoize[pair, int]())
+	for _, p := range []pair{{3, 7}, {3, 7}, {2, 2}, {3, 7}} {
+		h(context.Background(), p)
+	}
+	fmt.Println("  4 calls, 2 distinct inputs → real calls:", calls)
+
+	calls = 0
+	h = chain.Apply(add, chain.Memoize[pair, int](), chain.FailFirst[pair, int](1, &calls))
+	_, err1 := h(context.Background(), pair{1, 2})
+	_, err2 := h(context.Background(), pair{1, 2})
+	fmt.Printf("  errors are not cached: err1=%v, err2=%v, real calls=%d\n", err1, err2, calls)
+
+	// Timing OUTSIDE Retry measures the whole thing, backoff included;
+	// Timing INSIDE Retry measures every single attempt
+	var outer, inner []time.Duration
+	calls = 0
+	h = chain.Apply(add,
+		chain.Timing[pair, int](func(d time.Duration) { outer = append(outer, d) }),
+		chain.Retry[pair, int](3, chain.Exponential(2*time.Millisecond, 2*time.Millisecond)),
+		chain.Timing[pair, int](func(d time.Duration) { inner = append(inner, d) }),
+		chain.FailFirst[pair, int](2, &calls),
+	)
+	h(context.Background(), pair{1, 2})
+	fmt.Println("  Timing outside Retry (backoff included):", outer)
+	fmt.Println("  Timing inside Retry (one per attempt): ", inner)
+	fmt.Println()
+}
+
+// ----------------------------
+// 5. Same middleware, other types
+// ----------------------------
+func otherTypes() {
+	fmt.Println("🔹 5. Generic: the same middleware for another handler type")
+
+	type user struct{ Name string }
+	lookups := 0
+	lookup := func(_ context.Context, id string) (user, error) {
+		lookups++
+		return user{Name: strings.ToUpper(id)}, nil
+	}
+
+	var lines []string
+	logf := func(format string, args ...any) { lines = append(lines, fmt.Sprintf(format, args...)) }
+	h := chain.Apply(lookup, chain.Logging[string, user]("lookup", logf), chain.Memoize[string, user]())
+	h(context.Background(), "sadik")
+	u, _ := h(context.Background(), "sadik")
+	fmt.Printf("  Handler[string, user]: %+v after 2 calls, %d real lookup(s)\n", u, lookups)
+	for _, line := range lines {
+		fmt.Println("  log:", line)
+	}
+	fmt.Println()
+}
+
+func main() {
+	order()
+	loggingTiming()
+	retry()
+	memoize()
+	otherTypes()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) A middleware is a function that takes a function and returns a
+   function of the SAME type — so the result can be wrapped again.
+
+2) Order is everything:
+       Chain(a, b, c)(h)  → a runs first on the way in, last on the way out
+   - Timing outside Retry measures total latency, inside it measures
+     every attempt.
+   - Memoize outside Retry: a cached result skips the retries entirely.
+   - Logging outermost sees what the caller sees.
+
+3) State lives where it is declared (13.Closure):
+   - Memoize's cache is declared when the middleware wraps a handler,
+     so the returned closure keeps it across calls.
+   - Retry's attempt counter is a local of each call: concurrent calls
+     never share it.
+
+4) Generics let one implementation serve every Handler[In, Out];
+   Memoize additionally needs In to be comparable (it is a map key).
+
+5) net/http uses the same shape without generics:
+       func(http.Handler) http.Handler
+   33.Safe_Go's safego.Handler is one.
+====================================================
+*/
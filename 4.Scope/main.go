@@ -30,5 +30,5 @@ func main() {
 	mathlib.Add(a, b)
 	mathlib.Sum()
 	fmt.Println(mathlib.Money)
-	// add (z, a) // Doesnt work as z onky lives within add
+	// add (z, a) // Doesnt work as z onky lives within add (see 43.Compile_Fixtures)
 }
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+
+	r "go_projects/40.Rules_Engine/rules"
+)
+
+/*
+====================================================
+🔹 CONDITIONS AS DATA: A SMALL RULES ENGINE
+====================================================
+
+2.Conditions writes every decision as code:
+
+    if age > 18 { ... } else if age < 18 { ... } else if age == 18 { ... }
+    else { fmt.Println("You are an Alien") }
+
+Nothing tells you that the last else can never run. Here the same
+decisions are DATA:
+
+    marriage = r.MustNew[Person](
+        r.Rule{Name: "eligible",  When: r.Field("Age").Gt(18), Outcome: "..."},
+        r.Rule{Name: "too young", When: r.Field("Age").Lt(18), Outcome: "..."},
+        r.Rule{Name: "teenager",  When: r.Field("Age").Eq(18), Outcome: "..."},
+        r.Rule{Name: "alien",     When: r.Always(),           Outcome: "..."},
+    )
+
+   - Decide(person) → first matching rule (same as if / else if)
+   - Analyze()      → unreachable rules, overlapping rules, gaps
+
+Predicates: Field(name).Eq/Ne/Lt/Le/Gt/Ge(c), Is(boolField),
+            And(...), Or(...), Not(p), Always()
+
+The expected answers are table tests in rules/rules_test.go.
+
+Run it:
+    go run ./40.Rules_Engine
+    go test ./40.Rules_Engine/...
+====================================================
+*/
+
+// Person holds every input 2.Conditions checks
+type Person struct {
+	Age      int
+	Sex      string
+	IsPretty bool
+	Day      int
+	Mark     int
+}
+
+var (
+	marriage = r.MustNew[Person](
+		r.Rule{Name: "eligible", When: r.Field("Age").Gt(18), Outcome: "You are eligible to be married"},
+		r.Rule{Name: "too young", When: r.Field("Age").Lt(18), Outcome: "You are not eligible to be married but you can love someone"},
+		r.Rule{Name: "teenager", When: r.Field("Age").Eq(18), Outcome: "You are just a teenager, not eligible to be married"},
+		r.Rule{Name: "alien", When: r.Always(), Outcome: "You are an Alien"},
+	)
+
+	voting = r.MustNew[Person](
+		r.Rule{Name: "can vote", When: r.Field("Age").Ge(18), Outcome: "You can vote"},
+		r.Rule{Name: "cannot vote", When: r.Field("Age").Lt(18), Outcome: "No voting for you"},
+		r.Rule{Name: "alien", When: r.Always(), Outcome: "You are an alien"},
+	)
+
+	military = r.MustNew[Person](
+		r.Rule{Name: "eligible", When: r.And(r.Field("Age").Ge(18), r.Field("Sex").Eq("Male")),
+			Outcome: "You are eligible for military service"},
+		r.Rule{Name: "not eligible", When: r.Always(), Outcome: "You are not eligible for military service"},
+	)
+
+	// no else: a person who is not pretty gets no outcome (a gap)
+	dating = r.MustNew[Person](
+		r.Rule{Name: "pretty", When: r.Is("IsPretty"), Outcome: "You are eligible for dating"},
+	)
+
+	days = r.MustNew[Person](
+		r.Rule{Name: "monday", When: r.Field("Day").Eq(1), Outcome: "Monday"},
+		r.Rule{Name: "tuesday", When: r.Field("Day").Eq(2), Outcome: "Tuesday"},
+		r.Rule{Name: "wednesday", When: r.Field("Day").Eq(3), Outcome: "Wednesday"},
+		r.Rule{Name: "default", When: r.Always(), Outcome: "Another day"},
+	)
+
+	// the condition switch: every case relies on the ones above it
+	grades = r.MustNew[Person](
+		r.Rule{Name: "A", When: r.Field("Mark").Ge(90), Outcome: "Grade: A, GPA: 4.00"},
+		r.Rule{Name: "A-", When: r.Field("Mark").Ge(85), Outcome: "Grade: A-, GPA: 3.70"},
+		r.Rule{Name: "B+", When: r.Field("Mark").Ge(80), Outcome: "Grade: B+, GPA: 3.30"},
+		r.Rule{Name: "B", When: r.Field("Mark").Ge(75), Outcome: "Grade: B, GPA: 3.00"},
+		r.Rule{Name: "F", When: r.Always(), Outcome: "Grade: F, GPA: 0.00"},
+	)
+
+	// the same grades with explicit ranges: order no longer matters
+	gradeRanges = r.MustNew[Person](
+		r.Rule{Name: "A", When: r.Field("Mark").Ge(90), Outcome: "Grade: A, GPA: 4.00"},
+		r.Rule{Name: "A-", When: r.And(r.Field("Mark").Ge(85), r.Field("Mark").Lt(90)), Outcome: "Grade: A-, GPA: 3.70"},
+		r.Rule{Name: "B+", When: r.And(r.Field("Mark").Ge(80), r.Field("Mark").Lt(85)), Outcome: "Grade: B+, GPA: 3.30"},
+		r.Rule{Name: "B", When: r.And(r.Field("Mark").Ge(75), r.Field("Mark").Lt(80)), Outcome: "Grade: B, GPA: 3.00"},
+		r.Rule{Name: "F", When: r.Field("Mark").Lt(75), Outcome: "Grade: F, GPA: 0.00"},
+	)
+
+	// mistakes the analyzer must catch
+	broken = r.MustNew[Person](
+		r.Rule{Name: "adult", When: r.Field("Age").Ge(18), Outcome: "adult"},
+		r.Rule{Name: "senior", When: r.Field("Age").Gt(65), Outcome: "senior"}, // always taken by "adult"
+		r.Rule{Name: "young senior", When: r.And(r.Field("Age").Gt(65), r.Field("Age").Lt(18)), Outcome: "?"},
+		r.Rule{Name: "not male", When: r.Not(r.Field("Sex").Eq("Male")), Outcome: "not male"},
+	)
+)
+
+// ----------------------------
+// 1. Decide: the same answers as 2.Conditions
+// ----------------------------
+func decisions() {
+	fmt.Println("🔹 1. Decide rebuilds 2.Conditions' checks")
+
+	tests := []struct {
+		set  *r.Set[Person]
+		name string
+		in   Person
+	}{
+		{marriage, "marriage, age 20", Person{Age: 20}},
+		{marriage, "marriage, age 17", Person{Age: 17}},
+		{marriage, "marriage, age 18", Person{Age: 18}},
+		{voting, "voting, age 5", Person{Age: 5}},
+		{military, "military, 25 Male", Person{Age: 25, Sex: "Male"}},
+		{military, "military, 17 Male", Person{Age: 17, Sex: "Male"}},
+		{dating, "dating, pretty", Person{IsPretty: true}},
+		{dating, "dating, not pretty", Person{}},
+		{days, "day 3", Person{Day: 3}},
+		{days, "day 7", Person{Day: 7}},
+		{grades, "grades, mark 78", Person{Mark: 78}},
+		{gradeRanges, "ranges, mark 78", Person{Mark: 78}},
+	}
+	for _, tt := range tests {
+		rule, ok := tt.set.Decide(tt.in)
+		if !ok {
+			fmt.Printf("  %-20s → no rule matched\n", tt.name)
+			continue
+		}
+		fmt.Printf("  %-20s → [%s] %s\n", tt.name, rule.Name, rule.Outcome)
+	}
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. Analyze: what the if/else chains hide
+// ----------------------------
+func analysis() {
+	fmt.Println("🔹 2. Analyze finds unreachable rules, overlaps and gaps")
+
+	tests := []struct {
+		set  *r.Set[Person]
+		name string
+	}{
+		{marriage, "marriage"},
+		{voting, "voting"},
+		{military, "military"},
+		{dating, "dating"},
+		{days, "days"},
+		{grades, "grades (switch order)"},
+		{gradeRanges, "grades (ranges)"},
+		{broken, "broken"},
+	}
+	for _, tt := range tests {
+		findings := tt.set.Analyze()
+		fmt.Printf("  %-22s %d finding(s)\n", tt.name, len(findings))
+		for _, f := range findings {
+			fmt.Println("       ", f)
+		}
+	}
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. Mistakes caught by New
+// ----------------------------
+func validation() {
+	fmt.Println("🔹 3. New checks fields and types")
+
+	_, err := r.New[Person](r.Rule{Name: "typo", When: r.Field("Agee").Gt(18)})
+	fmt.Printf("  %-32s %v\n", "unknown field", err)
+	_, err = r.New[Person](r.Rule{Name: "wrong type", When: r.Field("Sex").Eq(1)})
+	fmt.Printf("  %-32s %v\n", "string field compared with int", err)
+	fmt.Println()
+}
+
+func main() {
+	decisions()
+	analysis()
+	validation()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) 2.Conditions' first chain:
+       age > 18  |  age < 18  |  age == 18  |  else "Alien"
+   age == 18 IS reachable (it is the only value left), but after it
+   nothing is: the else is dead code. Same for the voting chain.
+
+2) An ordered chain (if / else if, switch { case ...: }) is allowed to
+   overlap: `mark >= 85` is only reached when `mark >= 90` was false.
+   Correct, but every case depends on the ones above it — move one
+   and the grades change. Explicit ranges (gradeRanges) have no
+   overlaps, so their order does not matter.
+
+3) A rule set without an else can have gaps: inputs with no outcome
+   (dating: a person who is not pretty gets nothing).
+
+4) Why the analysis is exact: every condition compares a field with a
+   constant. The constants cut each field into regions; one value per
+   region (c-1, c, c+1, plus values beyond the extremes) is enough to
+   see every possible combination of true / false.
+
+5) Code vs data:
+   - Code (if/switch) is faster, type-checked, and fine for most logic.
+   - Data (rules) can be loaded from config, listed, tested
+     exhaustively and analyzed — useful when the rules change often.
+====================================================
+*/
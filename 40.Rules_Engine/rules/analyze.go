@@ -0,0 +1,198 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Finding is one problem in a rule set. Example is an input that shows it.
+type Finding struct {
+	Rule    string
+	Kind    string // "unreachable", "overlap", "gap", or "error" if the set cannot be analyzed
+	Detail  string
+	Example string
+}
+
+func (f Finding) String() string {
+	s := fmt.Sprintf("%-11s %s", f.Kind, f.Detail)
+	if f.Example != "" {
+		s += "  e.g. " + f.Example
+	}
+	return s
+}
+
+// maxPoints bounds the number of inputs Analyze tries
+const maxPoints = 1 << 20
+
+// Analyze finds:
+//   - unreachable rules: never true, or only true where an earlier rule
+//     already matched (the "You are an Alien" else in 2.Conditions),
+//   - overlaps: inputs where an earlier rule hides part of a later one,
+//   - gaps: inputs no rule matches.
+//
+// It is exact, not a guess: every comparison is against a constant, so
+// the inputs can be split into regions whose borders are those constants.
+// For an int constant c, the values c-1, c and c+1 (plus one value below
+// and above all constants) hit every region; strings use each constant
+// plus one other value; bools use both. Analyze evaluates the rules on
+// every combination of those values.
+func (s *Set[T]) Analyze() []Finding {
+	if s.typ == nil {
+		return []Finding{{Kind: "error", Detail: "rule set not created with New"}}
+	}
+	consts := map[string][]any{}
+	for _, r := range s.rules {
+		r.When.fields(consts)
+	}
+	names := make([]string, 0, len(consts))
+	for name := range consts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	axes := make([][]any, len(names))
+	total := 1
+	for i, name := range names {
+		f, _ := s.typ.FieldByName(name)
+		axes[i] = candidates(consts[name], f.Type)
+		total *= len(axes[i])
+		if total > maxPoints {
+			return []Finding{{Kind: "error", Detail: "too many combinations to analyze"}}
+		}
+	}
+
+	// matched[i][p]: rule i is true for point p
+	matched := make([][]bool, len(s.rules))
+	for i := range matched {
+		matched[i] = make([]bool, total)
+	}
+	points := make([]reflect.Value, total)
+	for p := range total {
+		v := reflect.New(s.typ).Elem()
+		rest := p
+		for i, name := range names {
+			axis := axes[i]
+			v.FieldByName(name).Set(reflect.ValueOf(axis[rest%len(axis)]).Convert(v.FieldByName(name).Type()))
+			rest /= len(axis)
+		}
+		points[p] = v
+		for i, r := range s.rules {
+			matched[i][p] = r.When.eval(v)
+		}
+	}
+	example := func(p int) string {
+		var parts []string
+		for _, name := range names {
+			parts = append(parts, fmt.Sprintf("%s=%v", name, points[p].FieldByName(name).Interface()))
+		}
+		return strings.Join(parts, " ")
+	}
+
+	var findings []Finding
+	for i, r := range s.rules {
+		firstTrue, firstFree := -1, -1 // a point where r matches / where it WINS
+		hiddenBy := map[string]int{}   // earlier rule → first point it hides
+		for p := range total {
+			if !matched[i][p] {
+				continue
+			}
+			if firstTrue < 0 {
+				firstTrue = p
+			}
+			winner := slices.IndexFunc(matched[:i], func(m []bool) bool { return m[p] })
+			if winner < 0 {
+				if firstFree < 0 {
+					firstFree = p
+				}
+				continue
+			}
+			if _, seen := hiddenBy[s.rules[winner].Name]; !seen {
+				hiddenBy[s.rules[winner].Name] = p
+			}
+		}
+
+		switch {
+		case firstTrue < 0:
+			findings = append(findings, Finding{Rule: r.Name, Kind: "unreachable",
+				Detail: fmt.Sprintf("%q (%v) is never true", r.Name, r.When)})
+		case firstFree < 0:
+			findings = append(findings, Finding{Rule: r.Name, Kind: "unreachable",
+				Detail: fmt.Sprintf("%q (%v) only matches inputs already taken by %s",
+					r.Name, r.When, quoteKeys(hiddenBy)), Example: example(firstTrue)})
+		default:
+			for _, earlier := range sortedKeys(hiddenBy) {
+				if _, isElse := r.When.(always); isElse {
+					break // an else overlaps everything by definition
+				}
+				findings = append(findings, Finding{Rule: r.Name, Kind: "overlap",
+					Detail:  fmt.Sprintf("%q is partly hidden by earlier %q", r.Name, earlier),
+					Example: example(hiddenBy[earlier])})
+			}
+		}
+	}
+
+	for p := range total {
+		if !slices.ContainsFunc(matched, func(m []bool) bool { return m[p] }) {
+			findings = append(findings, Finding{Kind: "gap", Detail: "no rule matches", Example: example(p)})
+			break
+		}
+	}
+	return findings
+}
+
+// candidates returns one value per region the constants split a field
+// of type t into. Int values that do not fit in t are left out: an int8
+// compared with 127 has no region above it.
+func candidates(values []any, t reflect.Type) []any {
+	switch values[0].(type) {
+	case int:
+		set := map[int]bool{}
+		lo, hi := values[0].(int), values[0].(int)
+		for _, v := range values {
+			n := v.(int)
+			set[n-1], set[n], set[n+1] = true, true, true
+			lo, hi = min(lo, n), max(hi, n)
+		}
+		set[lo-2], set[hi+2] = true, true
+		out := make([]any, 0, len(set))
+		for _, n := range slices.Sorted(maps.Keys(set)) {
+			if !reflect.Zero(t).OverflowInt(int64(n)) {
+				out = append(out, n)
+			}
+		}
+		return out
+	case string:
+		set := map[string]bool{"<other>": true}
+		for _, v := range values {
+			set[v.(string)] = true
+		}
+		out := make([]any, 0, len(set))
+		for _, s := range slices.Sorted(maps.Keys(set)) {
+			out = append(out, s)
+		}
+		return out
+	default:
+		return []any{false, true}
+	}
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return m[keys[i]] < m[keys[j]] })
+	return keys
+}
+
+func quoteKeys(m map[string]int) string {
+	keys := sortedKeys(m)
+	for i, k := range keys {
+		keys[i] = fmt.Sprintf("%q", k)
+	}
+	return strings.Join(keys, ", ")
+}
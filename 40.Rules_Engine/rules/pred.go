@@ -0,0 +1,187 @@
+// Package rules evaluates decision rules declared as data: predicates on
+// the fields of a struct, combined with AND / OR / NOT. Because the rules
+// are data and not code, the package can also analyze them (Analyze):
+// find rules that can never fire and rules that overlap.
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Pred is a condition on a struct value
+type Pred interface {
+	eval(v reflect.Value) bool
+	// fields adds every field the predicate reads, with the constants it
+	// is compared to
+	fields(consts map[string][]any)
+	String() string
+}
+
+// ----------------------------
+// Comparisons
+// ----------------------------
+
+type op string
+
+const (
+	eq op = "=="
+	ne op = "!="
+	lt op = "<"
+	le op = "<="
+	gt op = ">"
+	ge op = ">="
+)
+
+// cmp compares one field with a constant
+type cmp struct {
+	field string
+	op    op
+	value any // int, string or bool
+}
+
+// Field starts a comparison on the named struct field:
+//
+//	rules.Field("Age").Ge(18)
+func Field(name string) FieldRef { return FieldRef{name} }
+
+// FieldRef is a struct field waiting for its comparison
+type FieldRef struct{ name string }
+
+// Eq: field == v (int, string or bool)
+func (f FieldRef) Eq(v any) Pred { return cmp{f.name, eq, v} }
+
+// Ne: field != v (int, string or bool)
+func (f FieldRef) Ne(v any) Pred { return cmp{f.name, ne, v} }
+
+// Lt: field < n
+func (f FieldRef) Lt(n int) Pred { return cmp{f.name, lt, n} }
+
+// Le: field <= n
+func (f FieldRef) Le(n int) Pred { return cmp{f.name, le, n} }
+
+// Gt: field > n
+func (f FieldRef) Gt(n int) Pred { return cmp{f.name, gt, n} }
+
+// Ge: field >= n
+func (f FieldRef) Ge(n int) Pred { return cmp{f.name, ge, n} }
+
+// Is: the bool field is true
+func Is(name string) Pred { return cmp{name, eq, true} }
+
+func (c cmp) eval(v reflect.Value) bool {
+	f := v.FieldByName(c.field)
+	switch want := c.value.(type) {
+	case int:
+		return compare(f.Int(), int64(want), c.op)
+	case string:
+		return compare(f.String(), want, c.op)
+	case bool:
+		got := f.Bool()
+		if c.op == ne {
+			return got != want
+		}
+		return got == want
+	}
+	return false
+}
+
+func compare[T int64 | string](a, b T, o op) bool {
+	switch o {
+	case eq:
+		return a == b
+	case ne:
+		return a != b
+	case lt:
+		return a < b
+	case le:
+		return a <= b
+	case gt:
+		return a > b
+	case ge:
+		return a >= b
+	}
+	return false
+}
+
+func (c cmp) fields(consts map[string][]any) {
+	consts[c.field] = append(consts[c.field], c.value)
+}
+
+func (c cmp) String() string {
+	if b, ok := c.value.(bool); ok && c.op == eq && b {
+		return c.field
+	}
+	if s, ok := c.value.(string); ok {
+		return fmt.Sprintf("%s %s %q", c.field, c.op, s)
+	}
+	return fmt.Sprintf("%s %s %v", c.field, c.op, c.value)
+}
+
+// ----------------------------
+// AND / OR / NOT / Always
+// ----------------------------
+
+type and []Pred
+type or []Pred
+type not struct{ p Pred }
+type always struct{}
+
+// And is true when every p is true
+func And(ps ...Pred) Pred { return and(ps) }
+
+// Or is true when at least one p is true
+func Or(ps ...Pred) Pred { return or(ps) }
+
+// Not negates p
+func Not(p Pred) Pred { return not{p} }
+
+// Always is true for every input: the `else` of a rule set
+func Always() Pred { return always{} }
+
+func (a and) eval(v reflect.Value) bool {
+	for _, p := range a {
+		if !p.eval(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o or) eval(v reflect.Value) bool {
+	for _, p := range o {
+		if p.eval(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n not) eval(v reflect.Value) bool { return !n.p.eval(v) }
+func (always) eval(reflect.Value) bool  { return true }
+func (a and) fields(c map[string][]any) { each(a, c) }
+func (o or) fields(c map[string][]any)  { each(o, c) }
+func (n not) fields(c map[string][]any) { n.p.fields(c) }
+func (always) fields(map[string][]any)  {}
+func (a and) String() string            { return join(a, " AND ") }
+func (o or) String() string             { return join(o, " OR ") }
+func (n not) String() string            { return "NOT " + n.p.String() }
+func (always) String() string           { return "else" }
+
+func each(ps []Pred, c map[string][]any) {
+	for _, p := range ps {
+		p.fields(c)
+	}
+}
+
+func join(ps []Pred, sep string) string {
+	parts := make([]string, len(ps))
+	for i, p := range ps {
+		parts[i] = p.String()
+		if _, nested := p.(cmp); !nested {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Rule gives an Outcome when its condition holds
+type Rule struct {
+	Name    string
+	When    Pred
+	Outcome string
+}
+
+// Set is an ordered list of rules for inputs of type T. Like an
+// if / else if / else chain, the FIRST matching rule wins. Create it
+// with New or MustNew: they check the rules against T.
+type Set[T any] struct {
+	rules []Rule
+	typ   reflect.Type
+}
+
+// New checks that every field the rules read is an exported field of T,
+// with a type that matches its constants
+func New[T any](rules ...Rule) (*Set[T], error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("rules: %v is not a struct", typ)
+	}
+	for _, r := range rules {
+		consts := map[string][]any{}
+		r.When.fields(consts)
+		for name, values := range consts {
+			f, ok := typ.FieldByName(name)
+			if !ok {
+				return nil, fmt.Errorf("rules: rule %q: %v has no field %s", r.Name, typ, name)
+			}
+			if why := unsettable(typ, f.Index); why != "" {
+				return nil, fmt.Errorf("rules: rule %q: field %s of %v %s", r.Name, name, typ, why)
+			}
+			for _, v := range values {
+				if !fits(f.Type, v) {
+					return nil, fmt.Errorf("rules: rule %q: field %s is %v, compared with %T", r.Name, name, f.Type, v)
+				}
+			}
+		}
+	}
+	return &Set[T]{rules: rules, typ: typ}, nil
+}
+
+// MustNew is New for rule sets declared at package level
+func MustNew[T any](rules ...Rule) *Set[T] {
+	s, err := New[T](rules...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// unsettable says why Analyze could not set the field at index in a
+// new T, or returns "". reflect refuses to set unexported fields, and an
+// embedded pointer is nil in a new T.
+func unsettable(typ reflect.Type, index []int) string {
+	for _, i := range index {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			return "is not exported"
+		}
+		if f.Type.Kind() == reflect.Pointer {
+			return "is behind the embedded pointer " + f.Name
+		}
+		typ = f.Type
+	}
+	return ""
+}
+
+// fits reports whether the constant v can be stored in a field of type t
+func fits(t reflect.Type, v any) bool {
+	k := t.Kind()
+	switch v := v.(type) {
+	case int:
+		return k >= reflect.Int && k <= reflect.Int64 && !reflect.Zero(t).OverflowInt(int64(v))
+	case string:
+		return k == reflect.String
+	case bool:
+		return k == reflect.Bool
+	}
+	return false
+}
+
+// Rules returns a copy of the rules, in order
+func (s *Set[T]) Rules() []Rule {
+	return slices.Clone(s.rules)
+}
+
+// Decide returns the first rule that matches in
+func (s *Set[T]) Decide(in T) (Rule, bool) {
+	v := reflect.ValueOf(in)
+	for _, r := range s.rules {
+		if r.When.eval(v) {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
@@ -0,0 +1,231 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// person holds every input 2.Conditions checks
+type person struct {
+	Age      int
+	Sex      string
+	IsPretty bool
+	Day      int
+	Mark     int
+}
+
+var (
+	marriage = MustNew[person](
+		Rule{Name: "eligible", When: Field("Age").Gt(18), Outcome: "You are eligible to be married"},
+		Rule{Name: "too young", When: Field("Age").Lt(18), Outcome: "You are not eligible to be married but you can love someone"},
+		Rule{Name: "teenager", When: Field("Age").Eq(18), Outcome: "You are just a teenager, not eligible to be married"},
+		Rule{Name: "alien", When: Always(), Outcome: "You are an Alien"},
+	)
+
+	voting = MustNew[person](
+		Rule{Name: "can vote", When: Field("Age").Ge(18), Outcome: "You can vote"},
+		Rule{Name: "cannot vote", When: Field("Age").Lt(18), Outcome: "No voting for you"},
+		Rule{Name: "alien", When: Always(), Outcome: "You are an alien"},
+	)
+
+	military = MustNew[person](
+		Rule{Name: "eligible", When: And(Field("Age").Ge(18), Field("Sex").Eq("Male")),
+			Outcome: "You are eligible for military service"},
+		Rule{Name: "not eligible", When: Always(), Outcome: "You are not eligible for military service"},
+	)
+
+	dating = MustNew[person](
+		Rule{Name: "pretty", When: Is("IsPretty"), Outcome: "You are eligible for dating"},
+	)
+
+	days = MustNew[person](
+		Rule{Name: "monday", When: Field("Day").Eq(1), Outcome: "Monday"},
+		Rule{Name: "tuesday", When: Field("Day").Eq(2), Outcome: "Tuesday"},
+		Rule{Name: "wednesday", When: Field("Day").Eq(3), Outcome: "Wednesday"},
+		Rule{Name: "default", When: Always(), Outcome: "Another day"},
+	)
+
+	grades = MustNew[person](
+		Rule{Name: "A", When: Field("Mark").Ge(90), Outcome: "Grade: A, GPA: 4.00"},
+		Rule{Name: "A-", When: Field("Mark").Ge(85), Outcome: "Grade: A-, GPA: 3.70"},
+		Rule{Name: "B+", When: Field("Mark").Ge(80), Outcome: "Grade: B+, GPA: 3.30"},
+		Rule{Name: "B", When: Field("Mark").Ge(75), Outcome: "Grade: B, GPA: 3.00"},
+		Rule{Name: "F", When: Always(), Outcome: "Grade: F, GPA: 0.00"},
+	)
+
+	gradeRanges = MustNew[person](
+		Rule{Name: "A", When: Field("Mark").Ge(90), Outcome: "Grade: A, GPA: 4.00"},
+		Rule{Name: "A-", When: And(Field("Mark").Ge(85), Field("Mark").Lt(90)), Outcome: "Grade: A-, GPA: 3.70"},
+		Rule{Name: "B+", When: And(Field("Mark").Ge(80), Field("Mark").Lt(85)), Outcome: "Grade: B+, GPA: 3.30"},
+		Rule{Name: "B", When: And(Field("Mark").Ge(75), Field("Mark").Lt(80)), Outcome: "Grade: B, GPA: 3.00"},
+		Rule{Name: "F", When: Field("Mark").Lt(75), Outcome: "Grade: F, GPA: 0.00"},
+	)
+
+	broken = MustNew[person](
+		Rule{Name: "adult", When: Field("Age").Ge(18), Outcome: "adult"},
+		Rule{Name: "senior", When: Field("Age").Gt(65), Outcome: "senior"},
+		Rule{Name: "young senior", When: And(Field("Age").Gt(65), Field("Age").Lt(18)), Outcome: "?"},
+		Rule{Name: "not male", When: Not(Field("Sex").Eq("Male")), Outcome: "not male"},
+	)
+)
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		set  *Set[person]
+		name string
+		in   person
+		want string // outcome, "" = no rule matched
+	}{
+		{marriage, "marriage, age 20", person{Age: 20}, "You are eligible to be married"},
+		{marriage, "marriage, age 17", person{Age: 17}, "You are not eligible to be married but you can love someone"},
+		{marriage, "marriage, age 18", person{Age: 18}, "You are just a teenager, not eligible to be married"},
+		{voting, "voting, age 18", person{Age: 18}, "You can vote"},
+		{voting, "voting, age 5", person{Age: 5}, "No voting for you"},
+		{military, "military, 25 Male", person{Age: 25, Sex: "Male"}, "You are eligible for military service"},
+		{military, "military, 25 Female", person{Age: 25, Sex: "Female"}, "You are not eligible for military service"},
+		{military, "military, 17 Male", person{Age: 17, Sex: "Male"}, "You are not eligible for military service"},
+		{dating, "dating, pretty", person{IsPretty: true}, "You are eligible for dating"},
+		{dating, "dating, not pretty", person{}, ""},
+		{days, "day 3", person{Day: 3}, "Wednesday"},
+		{days, "day 7", person{Day: 7}, "Another day"},
+		{grades, "grades, mark 78", person{Mark: 78}, "Grade: B, GPA: 3.00"},
+		{grades, "grades, mark 90", person{Mark: 90}, "Grade: A, GPA: 4.00"},
+		{gradeRanges, "ranges, mark 78", person{Mark: 78}, "Grade: B, GPA: 3.00"},
+		{gradeRanges, "ranges, mark 12", person{Mark: 12}, "Grade: F, GPA: 0.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := tt.set.Decide(tt.in)
+			if rule.Outcome != tt.want || ok != (tt.want != "") {
+				t.Errorf("got %q (matched %v), want %q", rule.Outcome, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		set  *Set[person]
+		name string
+		want []string // "kind rule" for every finding
+	}{
+		// age > 18, < 18, == 18 cover every int: the else is dead code
+		{marriage, "marriage", []string{"unreachable alien"}},
+		{voting, "voting", []string{"unreachable alien"}},
+		{military, "military", nil},
+		{dating, "dating", []string{"gap "}},
+		{days, "days", nil},
+		// every >= case is partly hidden by the cases above it
+		{grades, "grades (switch order)", []string{"overlap A-", "overlap B+", "overlap B+", "overlap B", "overlap B", "overlap B"}},
+		{gradeRanges, "grades (ranges)", nil},
+		{broken, "broken", []string{"unreachable senior", "unreachable young senior", "overlap not male", "gap "}},
+		// a Set built without New has no type to analyze
+		{&Set[person]{}, "zero Set", []string{"error "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range tt.set.Analyze() {
+				got = append(got, f.Kind+" "+f.Rule)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("findings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// inputs that Analyze could not fill in with reflect
+type (
+	lower   struct{ age int }
+	Inner   struct{ Age int }
+	viaPtr  struct{ *Inner }
+	hidden  struct{ inner }
+	inner   struct{ Age int }
+	level   struct{ Level int8 }
+	promote struct{ Inner }
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		new     func() error
+		wantErr string // "" = accepted
+	}{
+		{"unknown field", func() error {
+			_, err := New[person](Rule{Name: "typo", When: Field("Agee").Gt(18)})
+			return err
+		}, `rules: rule "typo": rules.person has no field Agee`},
+		{"string field compared with int", func() error {
+			_, err := New[person](Rule{Name: "wrong type", When: Field("Sex").Eq(1)})
+			return err
+		}, `rules: rule "wrong type": field Sex is string, compared with int`},
+		{"unexported field", func() error {
+			_, err := New[lower](Rule{Name: "adult", When: Field("age").Ge(18)})
+			return err
+		}, `rules: rule "adult": field age of rules.lower is not exported`},
+		{"field of an unexported embedded struct", func() error {
+			_, err := New[hidden](Rule{Name: "adult", When: Field("Age").Ge(18)})
+			return err
+		}, `rules: rule "adult": field Age of rules.hidden is not exported`},
+		{"field behind an embedded pointer", func() error {
+			_, err := New[viaPtr](Rule{Name: "adult", When: Field("Age").Ge(18)})
+			return err
+		}, `rules: rule "adult": field Age of rules.viaPtr is behind the embedded pointer Inner`},
+		{"promoted field", func() error {
+			_, err := New[promote](Rule{Name: "adult", When: Field("Age").Ge(18)})
+			return err
+		}, ""},
+		{"constant too big for int8", func() error {
+			_, err := New[level](Rule{Name: "max", When: Field("Level").Eq(200)})
+			return err
+		}, `rules: rule "max": field Level is int8, compared with int`},
+		{"not a struct", func() error {
+			_, err := New[int]()
+			return err
+		}, "rules: int is not a struct"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.new()
+			if got := fmt.Sprint(err); (err == nil) != (tt.wantErr == "") || err != nil && got != tt.wantErr {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAnalyzeSmallInt(t *testing.T) {
+	// an int8 cannot be above 127: no gap, and no example that wrapped
+	// around to -128
+	levels := MustNew[level](
+		Rule{Name: "low", When: Field("Level").Lt(127), Outcome: "low"},
+		Rule{Name: "max", When: Field("Level").Eq(127), Outcome: "max"},
+	)
+	if got := levels.Analyze(); len(got) != 0 {
+		t.Errorf("Analyze() = %v, want no findings", got)
+	}
+
+	// the embedded struct's field is set through the promotion
+	adults := MustNew[promote](Rule{Name: "adult", When: Field("Age").Ge(18)})
+	if got := adults.Analyze(); len(got) != 1 || got[0].Kind != "gap" || got[0].Example != "Age=16" {
+		t.Errorf("Analyze() = %v, want one gap at Age=16", got)
+	}
+}
+
+func TestCandidatesExtremes(t *testing.T) {
+	// a - b overflows for these two: the sort must not rely on it
+	var got []int
+	for _, n := range candidates([]any{math.MinInt + 5, math.MaxInt - 5}, reflect.TypeFor[int]()) {
+		got = append(got, n.(int))
+	}
+	if !slices.IsSorted(got) {
+		t.Errorf("candidates are not sorted: %v", got)
+	}
+	if want := []any{125, 126, 127}; !slices.Equal(candidates([]any{127}, reflect.TypeFor[int8]()), want) {
+		t.Errorf("candidates(127) for int8 = %v, want %v", candidates([]any{127}, reflect.TypeFor[int8]()), want)
+	}
+}
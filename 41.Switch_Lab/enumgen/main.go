@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/constant"
+	"go/format"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+/*
+====================================================
+🔹 ENUMGEN: A TINY `stringer`
+====================================================
+
+Writes a String() method for an iota "enum" type, so fmt prints
+Monday instead of 1.
+
+Used through go:generate (see 41.Switch_Lab/weekday/weekday.go):
+
+    //go:generate go run go_projects/41.Switch_Lab/enumgen -type=Weekday
+
+    go generate ./41.Switch_Lab/...
+
+go generate runs the command in the package's directory; enumgen loads
+that package, finds every constant of the type, and writes
+<type>_string.go next to it (lowercase type name).
+====================================================
+*/
+
+func main() {
+	typeName := flag.String("type", "", "name of the enum type (required)")
+	flag.Parse()
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	src, err := generate(dir, *typeName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "enumgen:", err)
+		os.Exit(1)
+	}
+	out := filepath.Join(dir, strings.ToLower(*typeName)+"_string.go")
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "enumgen:", err)
+		os.Exit(1)
+	}
+}
+
+// member is one constant of the enum type
+type member struct {
+	name  string
+	value constant.Value
+}
+
+func generate(dir, typeName string) ([]byte, error) {
+	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedTypes, Dir: dir}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("cannot load package in %s: found %d packages", dir, len(pkgs))
+	}
+	if len(pkgs[0].Errors) > 0 {
+		return nil, fmt.Errorf("cannot load package in %s: %v", dir, pkgs[0].Errors)
+	}
+	pkg := pkgs[0].Types
+
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("no type %s in package %s", typeName, pkg.Name())
+	}
+	basic, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return nil, fmt.Errorf("%s is not an integer type", typeName)
+	}
+
+	var members []member
+	seen := map[string]bool{}
+	for _, name := range pkg.Scope().Names() {
+		c, ok := pkg.Scope().Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), obj.Type()) || name == "_" {
+			continue
+		}
+		members = append(members, member{name, c.Val()})
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		return constant.Compare(members[i].value, token.LSS, members[j].value)
+	})
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by enumgen -type=%s; DO NOT EDIT.\n\n", typeName)
+	fmt.Fprintf(&b, "package %s\n\n", pkg.Name())
+	fmt.Fprintf(&b, "import \"strconv\"\n\n")
+	fmt.Fprintf(&b, "func (i %s) String() string {\n\tswitch i {\n", typeName)
+	for _, m := range members {
+		// two constants with the same value (an alias) would be a
+		// duplicate case: the first name wins
+		if seen[m.value.ExactString()] {
+			continue
+		}
+		seen[m.value.ExactString()] = true
+		fmt.Fprintf(&b, "\tcase %s:\n\t\treturn %q\n", m.name, m.name)
+	}
+	fmt.Fprintf(&b, "\t}\n\treturn \"%s(\" + strconv.FormatInt(int64(i), 10) + \")\"\n}\n", typeName)
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,108 @@
+// Package exhaustive defines an analyzer that reports switch statements
+// over enum-like types that do not list every constant of the type.
+//
+// An enum-like type is a defined type with an integer or string
+// underlying type whose package declares at least two constants of it
+// (weekday.Weekday, time.Month, reflect.Kind, ...).
+package exhaustive
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer reports non-exhaustive enum switches
+var Analyzer = &analysis.Analyzer{
+	Name:     "exhaustive",
+	Doc:      "report switches over enum-like constants that miss some of them",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// strict also checks switches that have a default case: a default hides
+// the constants added to the type later
+var strict bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&strict, "strict", false, "also report switches that have a default case")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.SwitchStmt)(nil)}, func(n ast.Node) {
+		sw := n.(*ast.SwitchStmt)
+		if sw.Tag == nil {
+			return // switch { case cond: } has no enum to be exhaustive over
+		}
+		named, ok := types.Unalias(pass.TypesInfo.TypeOf(sw.Tag)).(*types.Named)
+		if !ok {
+			return
+		}
+		members := enumMembers(named, pass.Pkg)
+		if len(members) < 2 {
+			return
+		}
+
+		covered := map[string]bool{}
+		for _, stmt := range sw.Body.List {
+			clause := stmt.(*ast.CaseClause)
+			if clause.List == nil && !strict {
+				return // default
+			}
+			for _, e := range clause.List {
+				if tv, ok := pass.TypesInfo.Types[e]; ok && tv.Value != nil {
+					covered[tv.Value.ExactString()] = true
+				}
+			}
+		}
+
+		var missing []string
+		for _, m := range members {
+			if !covered[m.Val().ExactString()] {
+				covered[m.Val().ExactString()] = true // report aliases once
+				missing = append(missing, m.Name())
+			}
+		}
+		if len(missing) > 0 {
+			pass.Reportf(sw.Pos(), "missing cases in switch of type %s: %s",
+				types.TypeString(named, types.RelativeTo(pass.Pkg)), strings.Join(missing, ", "))
+		}
+	})
+	return nil, nil
+}
+
+// enumMembers returns the constants of type named declared in its own
+// package, in declaration order. From another package only the exported
+// ones count: the others cannot be written in a case.
+func enumMembers(named *types.Named, from *types.Package) []*types.Const {
+	obj := named.Obj()
+	if obj.Pkg() == nil {
+		return nil // error and other universe types
+	}
+	basic, ok := named.Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsInteger|types.IsString) == 0 {
+		return nil
+	}
+
+	var members []*types.Const
+	scope := obj.Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) || name == "_" {
+			continue
+		}
+		if obj.Pkg() != from && !c.Exported() {
+			continue
+		}
+		members = append(members, c)
+	}
+	// Names() is sorted alphabetically; report in declaration order
+	sort.Slice(members, func(i, j int) bool { return members[i].Pos() < members[j].Pos() })
+	return members
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"go_projects/41.Switch_Lab/exhaustive"
+)
+
+/*
+====================================================
+🔹 EXHAUSTIVE SWITCH CHECK
+====================================================
+
+Runs the exhaustive analyzer (package ../exhaustive):
+
+    go run ./41.Switch_Lab/exhaustivecheck ./...
+    go run ./41.Switch_Lab/exhaustivecheck -strict ./41.Switch_Lab
+
+Without -strict a `default:` counts as covering everything.
+With -strict switches with a default are checked too.
+Exit status 3 means something was reported (like go vet).
+====================================================
+*/
+
+func main() {
+	singlechecker.Main(exhaustive.Analyzer)
+}
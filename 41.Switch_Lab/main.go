@@ -0,0 +1,317 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go_projects/41.Switch_Lab/weekday"
+)
+
+/*
+====================================================
+🔹 EVERY SWITCH GO HAS
+====================================================
+
+2.Conditions shows two forms: `switch day { case 1: ... }` and
+`switch { case mark >= 90: ... }`. Go has more:
+
+   1. expression switch, several values per case
+   2. switch with an init statement
+   3. tagless switch (conditions), also with init
+   4. fallthrough
+   5. break: leaves the SWITCH, not the loop (labels fix that)
+   6. cases that are function calls: evaluated top to bottom, lazily
+   7. type switch: single type, several types, nil, default
+   8. type switch inside a generic function
+   9. switch over an iota "enum" — and what the exhaustive analyzer says
+
+Weekday (./weekday) is an iota enum; its String() method is generated
+by ./enumgen:
+
+    go generate ./41.Switch_Lab/...
+
+Find switches that forget an enum value (section 9 has one on purpose):
+
+    go run ./41.Switch_Lab/exhaustivecheck ./41.Switch_Lab/...
+
+The expected results are table tests in main_test.go.
+
+Run it:
+    go run ./41.Switch_Lab
+    go test ./41.Switch_Lab/...
+====================================================
+*/
+
+// ----------------------------
+// 1-3. Expression, init, tagless
+// ----------------------------
+
+// kind uses an expression switch with several values per case
+func kind(d weekday.Weekday) string {
+	switch d {
+	case weekday.Saturday, weekday.Sunday:
+		return "weekend"
+	case weekday.Monday, weekday.Tuesday, weekday.Wednesday, weekday.Thursday, weekday.Friday:
+		return "workday"
+	default:
+		return "not a day"
+	}
+}
+
+// dayOf has an init statement: d only exists inside the switch
+func dayOf(n int) string {
+	switch d := weekday.Weekday(n % 7); d {
+	case weekday.Friday:
+		return d.String() + "!"
+	default:
+		return d.String()
+	}
+}
+
+// greeting is a tagless switch (same as `switch true`), with an init
+func greeting(hour int) string {
+	switch h := hour % 24; {
+	case h < 12:
+		return "good morning"
+	case h < 18:
+		return "good afternoon"
+	default:
+		return "good evening"
+	}
+}
+
+func basics() {
+	fmt.Println("🔹 1-3. Expression switch, init statement, tagless switch")
+	fmt.Printf("  %-28s %s\n", "kind(Saturday)", kind(weekday.Saturday))
+	fmt.Printf("  %-28s %s\n", "kind(Wednesday)", kind(weekday.Wednesday))
+	fmt.Printf("  %-28s %s\n", "kind(Weekday(9))", kind(weekday.Weekday(9)))
+	fmt.Printf("  %-28s %s\n", "dayOf(12) → 12%7", dayOf(12))
+	fmt.Printf("  %-28s %s\n", "greeting(15)", greeting(15))
+	fmt.Printf("  %-28s %v\n", "Monday, Weekday(9)", fmt.Sprint(weekday.Monday, weekday.Weekday(9))) // generated String()
+	fmt.Println()
+}
+
+// ----------------------------
+// 4-5. fallthrough and break
+// ----------------------------
+
+// perks uses fallthrough: the NEXT case body runs without checking its
+// condition. It must be the last statement of a case.
+func perks(level string) []string {
+	var got []string
+	switch level {
+	case "gold":
+		got = append(got, "lounge")
+		fallthrough
+	case "silver":
+		got = append(got, "priority boarding")
+		fallthrough
+	case "bronze":
+		got = append(got, "miles")
+	}
+	return got
+}
+
+// firstNegative shows that `break` inside a switch only leaves the
+// switch; a label is needed to leave the loop
+func firstNegative(nums []int) (plain, labeled int) {
+	plain, labeled = -1, -1
+	for i, n := range nums {
+		switch {
+		case n < 0:
+			if plain < 0 {
+				plain = i
+			}
+			break // leaves the switch; the loop goes on
+		}
+	}
+
+loop:
+	for i, n := range nums {
+		switch {
+		case n < 0:
+			labeled = i
+			break loop // leaves the for loop
+		}
+	}
+	return plain, labeled
+}
+
+func fallthroughBreak() {
+	fmt.Println("🔹 4-5. fallthrough and break")
+	for _, level := range []string{"gold", "silver", "bronze", "iron"} {
+		fmt.Printf("  perks(%-8q) %q\n", level, perks(level))
+	}
+	// same answer, but the plain loop needs its `if`: `break` only left
+	// the switch, so the loop went on to -3
+	plain, labeled := firstNegative([]int{4, -1, 7, -3})
+	fmt.Printf("  firstNegative([4 -1 7 -3]) plain=%d labeled=%d\n", plain, labeled)
+	fmt.Println()
+}
+
+// ----------------------------
+// 6. Non-constant cases
+// ----------------------------
+
+// classify's cases are function calls. They run top to bottom and stop
+// at the first match: later cases are never evaluated.
+func classify(n int, calls *[]string) string {
+	probe := func(name string, ok bool) bool {
+		*calls = append(*calls, name)
+		return ok
+	}
+	switch {
+	case probe("negative", n < 0):
+		return "negative"
+	case probe("zero", n == 0):
+		return "zero"
+	case probe("small", n < 10):
+		return "small"
+	case probe("large", true):
+		return "large"
+	}
+	return ""
+}
+
+func lazyCases() {
+	fmt.Println("🔹 6. Cases are evaluated lazily, top to bottom")
+	for _, n := range []int{-5, 0, 70} {
+		var calls []string
+		got := classify(n, &calls)
+		fmt.Printf("  classify(%-2d) = %-8s cases evaluated: %s\n", n, got, strings.Join(calls, " → "))
+	}
+	fmt.Println()
+}
+
+// ----------------------------
+// 7-8. Type switches
+// ----------------------------
+
+// describe covers every type switch form. In a case with ONE type, v has
+// that type; with SEVERAL types (or default), v keeps the interface type.
+// fallthrough is not allowed in a type switch. The ORDER of interface
+// cases matters: a type with Error() AND String() takes the error case.
+func describe(x any) string {
+	switch v := x.(type) {
+	case nil:
+		return "nil"
+	case int:
+		return fmt.Sprintf("int, doubled %d", v*2) // v is int
+	case string:
+		return fmt.Sprintf("string of %d bytes", len(v)) // v is string
+	case int8, int16, int32, int64:
+		return fmt.Sprintf("sized int %T", v) // v is any
+	case error:
+		return "error: " + v.Error() // interfaces work as cases too
+	case fmt.Stringer:
+		return "Stringer: " + v.String()
+	default:
+		return fmt.Sprintf("something else: %T", v)
+	}
+}
+
+// zeroName switches on a TYPE PARAMETER: convert to any first
+func zeroName[T any]() string {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return "int"
+	case string:
+		return "string"
+	default:
+		return fmt.Sprintf("%T", zero)
+	}
+}
+
+func typeSwitches() {
+	fmt.Println("🔹 7-8. Type switches")
+	for _, in := range []any{nil, 21, "gopher", int8(1), io.EOF, weekday.Friday, 3.14} {
+		fmt.Printf("  describe(%-19T) %s\n", in, describe(in))
+	}
+	fmt.Printf("  %-29s %s\n", "zeroName[string]()", zeroName[string]())
+	fmt.Printf("  %-29s %s\n", "zeroName[bool]()", zeroName[bool]())
+	fmt.Println()
+}
+
+// ----------------------------
+// 9. Enum switches and exhaustiveness
+// ----------------------------
+
+// plan forgot Sunday. It compiles, and plan(Sunday) silently returns "".
+// `go run ./41.Switch_Lab/exhaustivecheck ./41.Switch_Lab` reports it:
+//
+//	missing cases in switch of type go_projects/41.Switch_Lab/weekday.Weekday: Sunday
+func plan(d weekday.Weekday) string {
+	switch d {
+	case weekday.Monday, weekday.Tuesday, weekday.Wednesday, weekday.Thursday, weekday.Friday:
+		return "work"
+	case weekday.Saturday:
+		return "rest"
+	}
+	return ""
+}
+
+var errUnknownDay = errors.New("unknown day")
+
+// planChecked handles every day, plus out-of-range values with a default:
+// exhaustive is happy (with or without -strict)
+func planChecked(d weekday.Weekday) (string, error) {
+	switch d {
+	case weekday.Monday, weekday.Tuesday, weekday.Wednesday, weekday.Thursday, weekday.Friday:
+		return "work", nil
+	case weekday.Saturday, weekday.Sunday:
+		return "rest", nil
+	default:
+		return "", fmt.Errorf("%v: %w", d, errUnknownDay)
+	}
+}
+
+func enums() {
+	fmt.Println("🔹 9. Switching over an iota enum")
+	fmt.Printf("  %-26s %q  ← the forgotten case\n", "plan(Sunday)", plan(weekday.Sunday))
+	got, _ := planChecked(weekday.Sunday)
+	fmt.Printf("  %-26s %q\n", "planChecked(Sunday)", got)
+	_, err := planChecked(weekday.Weekday(9))
+	fmt.Printf("  %-26s err=%v (is errUnknownDay: %v)\n", "planChecked(Weekday(9))", err, errors.Is(err, errUnknownDay))
+	fmt.Printf("  %-26s Sunday=%v Monday=%v\n", "IsWeekend", weekday.Sunday.IsWeekend(), weekday.Monday.IsWeekend())
+	fmt.Println()
+}
+
+func main() {
+	basics()
+	fallthroughBreak()
+	lazyCases()
+	typeSwitches()
+	enums()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) Go cases do NOT fall through (unlike C). `fallthrough` is explicit,
+   unconditional (the next case's condition is not checked), and not
+   allowed in a type switch or in the last case.
+
+2) `break` in a switch leaves the switch. Inside a for loop, use a
+   label (`break loop`) to leave the loop.
+
+3) Duplicate CONSTANT cases are compile errors (case 1: ... case 1:).
+   Non-constant cases are evaluated in order and only until one matches.
+
+4) Type switch variable:
+       case int:            v is int
+       case int8, int16:    v is the interface type (any)
+       case nil:            x was a nil interface
+
+5) iota enums are just named integers: Weekday(9) is a valid value,
+   and the compiler never checks that a switch covers every constant.
+   The exhaustive analyzer does:
+       go run ./41.Switch_Lab/exhaustivecheck ./41.Switch_Lab/...
+   A `default:` satisfies it, unless you pass -strict — a default can
+   hide a constant added later (with -strict, dayOf is reported too).
+====================================================
+*/
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"go_projects/41.Switch_Lab/weekday"
+)
+
+func TestBasics(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"kind(Saturday)", kind(weekday.Saturday), "weekend"},
+		{"kind(Wednesday)", kind(weekday.Wednesday), "workday"},
+		{"kind(Weekday(9)) hits default", kind(weekday.Weekday(9)), "not a day"},
+		{"dayOf(12) → 12%7 = Friday", dayOf(12), "Friday!"},
+		{"greeting(3)", greeting(3), "good morning"},
+		{"greeting(15)", greeting(15), "good afternoon"},
+		{"greeting(44) → 44%24 = 20", greeting(44), "good evening"},
+		{"generated String()", fmt.Sprint(weekday.Monday), "Monday"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestPerks(t *testing.T) {
+	tests := []struct {
+		level string
+		want  []string
+	}{
+		{"gold", []string{"lounge", "priority boarding", "miles"}}, // falls through twice
+		{"silver", []string{"priority boarding", "miles"}},
+		{"bronze", []string{"miles"}},
+		{"iron", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			if got := perks(tt.level); !slices.Equal(got, tt.want) {
+				t.Errorf("perks(%q) = %q, want %q", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstNegative(t *testing.T) {
+	plain, labeled := firstNegative([]int{4, -1, 7, -3})
+	if plain != 1 || labeled != 1 {
+		t.Errorf("plain=%d labeled=%d, want 1 1", plain, labeled)
+	}
+	if plain, labeled := firstNegative([]int{1, 2}); plain != -1 || labeled != -1 {
+		t.Errorf("no negatives: plain=%d labeled=%d, want -1 -1", plain, labeled)
+	}
+}
+
+func TestClassifyIsLazy(t *testing.T) {
+	tests := []struct {
+		n         int
+		want      string
+		wantCalls []string
+	}{
+		{-5, "negative", []string{"negative"}},
+		{0, "zero", []string{"negative", "zero"}},
+		{7, "small", []string{"negative", "zero", "small"}},
+		{70, "large", []string{"negative", "zero", "small", "large"}},
+	}
+	for _, tt := range tests {
+		var calls []string
+		got := classify(tt.n, &calls)
+		if got != tt.want || !slices.Equal(calls, tt.wantCalls) {
+			t.Errorf("classify(%d) = %q after %q, want %q after %q", tt.n, got, calls, tt.want, tt.wantCalls)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{nil, "nil"},
+		{21, "int, doubled 42"},
+		{"gopher", "string of 6 bytes"},
+		{int8(1), "sized int int8"},
+		{io.EOF, "error: EOF"},
+		{weekday.Friday, "Stringer: Friday"}, // generated String()
+		{3.14, "something else: float64"},
+	}
+	for _, tt := range tests {
+		if got := describe(tt.in); got != tt.want {
+			t.Errorf("describe(%#v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestZeroName(t *testing.T) {
+	if got := zeroName[string](); got != "string" {
+		t.Errorf("zeroName[string]() = %q", got)
+	}
+	if got := zeroName[bool](); got != "bool" {
+		t.Errorf("zeroName[bool]() = %q", got)
+	}
+}
+
+func TestEnums(t *testing.T) {
+	// the forgotten case: compiles, and silently returns ""
+	if got := plan(weekday.Sunday); got != "" {
+		t.Errorf("plan(Sunday) = %q, want \"\"", got)
+	}
+	for d := weekday.Sunday; d <= weekday.Saturday; d++ {
+		got, err := planChecked(d)
+		want := "work"
+		if d.IsWeekend() {
+			want = "rest"
+		}
+		if got != want || err != nil {
+			t.Errorf("planChecked(%v) = %q, %v, want %q", d, got, err, want)
+		}
+	}
+	if _, err := planChecked(weekday.Weekday(9)); !errors.Is(err, errUnknownDay) {
+		t.Errorf("planChecked(Weekday(9)) err = %v, want errUnknownDay", err)
+	}
+}
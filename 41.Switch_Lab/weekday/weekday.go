@@ -0,0 +1,30 @@
+// Package weekday defines an enum-like type with iota. Its String method
+// is generated by 41.Switch_Lab/enumgen.
+package weekday
+
+//go:generate go run go_projects/41.Switch_Lab/enumgen -type=Weekday
+
+// Weekday is a day of the week; the zero value is Sunday, like time.Weekday
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
+
+// IsWeekend uses a switch that lists every Weekday: the exhaustive
+// analyzer has nothing to report here
+func (d Weekday) IsWeekend() bool {
+	switch d {
+	case Saturday, Sunday:
+		return true
+	case Monday, Tuesday, Wednesday, Thursday, Friday:
+		return false
+	}
+	return false // out-of-range values like Weekday(9)
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"time"
+
+	"go_projects/42.Untyped_Constants/units"
+	"go_projects/43.Compile_Fixtures/fixture"
+)
+
+/*
+====================================================
+🔹 CONSTANTS LIVE IN THE COMPILER, NOT IN MEMORY
+====================================================
+
+1.Variables declares two constants:
+
+    const Z int = 11 // typed: Z is an int, with all of int's limits
+    const W = true   // untyped: just a value, a type is picked on use
+
+13.Closure says a constant is "stored in code segment". It is not
+stored anywhere: the compiler computes every constant expression and
+pastes the RESULT wherever it is used. A constant has no address.
+
+   1. untyped arithmetic is exact: 1 << 100 >> 98 == 4
+   2. the limits only apply when a constant meets a type
+   3. mistakes with constants are COMPILE errors, variables wrap silently
+   4. typed vs untyped: a typed Z cannot become a float64, an untyped one can
+   5. iota: bit flags, byte sizes (./units)
+
+The compile errors are real: every snippet in sections 3 and 4 is
+type-checked with go/types, the same checker `go vet` and gopls use,
+through 43.Compile_Fixtures' fixture.Check. The expected errors are
+table tests in main_test.go and units/units_test.go.
+
+Run it:
+    go run ./42.Untyped_Constants
+    go test ./42.Untyped_Constants/...
+====================================================
+*/
+
+// Big does not fit in any Go integer type. That is fine as long as it
+// is never used as one.
+const Big = 1 << 100
+
+// ----------------------------
+// 1. Exact, arbitrary precision arithmetic
+// ----------------------------
+func precision() {
+	fmt.Println("🔹 1. Untyped constant arithmetic is exact")
+
+	fmt.Printf("  %-36s %v\n", "Big >> 98 (Big itself overflows int)", Big>>98)
+	fmt.Printf("  %-36s %v\n", "float64(Big)", float64(Big))
+
+	// constant 0.1, 0.2 and 0.3 are exact fractions...
+	const sumOK = 0.1+0.2 == 0.3
+	// ...float64 variables are rounded to binary
+	x, y := 0.1, 0.2
+	fmt.Printf("  %-36s %v\n", "const 0.1+0.2 == 0.3", sumOK)
+	fmt.Printf("  %-36s %v (== 0.3: %v)\n", "float64 0.1+0.2", x+y, x+y == 0.3)
+
+	const third = 1.0 / 3
+	fmt.Printf("  %-36s %v\n", "const (1.0/3)*3", third*3)
+
+	// the compiler already knows the value: go/types reports it
+	value, err := constValue("1 << 100 >> 98")
+	fmt.Printf("  %-36s value=%s err=%v\n", "go/types folds 1 << 100 >> 98", value, err)
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. Default types
+// ----------------------------
+func defaults() {
+	fmt.Println("🔹 2. An untyped constant gets its DEFAULT type only when it needs one")
+
+	tests := []struct {
+		name string
+		v    any // passing to `any` forces a type
+	}{
+		{"1", 1},
+		{"1.0", 1.0},
+		{"'a'", 'a'}, // rune
+		{"1i", 1i},
+		{"\"go\"", "go"},
+		{"1 < 2", 1 < 2},
+		{"1 + 1.5 (float wins over int)", 1 + 1.5},
+	}
+	for _, tt := range tests {
+		fmt.Printf("  %-30s %T\n", tt.name, tt.v)
+	}
+
+	// an untyped 1 becomes a float64 here, no conversion needed
+	var f float64 = 1
+	fmt.Printf("  %-30s %T\n", "var f float64 = 1", f)
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. Compile errors vs silent wrapping
+// ----------------------------
+
+// overflowSnippets are function bodies with constant mistakes
+var overflowSnippets = []struct{ name, body string }{
+	{"int8 overflow", "var x int8 = 128; _ = x"},
+	{"uint from -1", "var u uint = -1; _ = u"},
+	{"Big used as an int", "const Big = 1 << 100; var x = Big; _ = x"},
+	{"Big >> 98 used as an int", "const Big = 1 << 100; var x = Big >> 98; _ = x"},
+	{"typed constant overflows its type", "const Z int8 = 1 << 7; _ = Z"},
+	{"float constant beyond float64", "const huge = 1e400; var f = huge; _ = f"},
+	{"constant division by zero", "x := 10 / 0; _ = x"},
+	{"assign to a constant", "const Z int = 11; Z = 12"},
+	{"address of a constant", "const Z int = 11; p := &Z; _ = p"},
+}
+
+func compileErrors() {
+	fmt.Println("🔹 3. Constant mistakes do not compile")
+
+	for _, sn := range overflowSnippets {
+		fmt.Printf("  %-34s %s\n", sn.name, firstError(compile(sn.body)))
+	}
+
+	// the same overflow with a VARIABLE compiles, and wraps at run time
+	var i int8 = 127
+	i++
+	fmt.Printf("  %-34s %d\n", "var int8 127, i++ wraps silently", i)
+	fmt.Println()
+}
+
+// ----------------------------
+// 4. Typed vs untyped
+// ----------------------------
+
+// typedSnippets compare 1.Variables' typed Z with an untyped W
+var typedSnippets = []struct{ name, body string }{
+	{"typed: const Z int → float64", "const Z int = 11; var f float64 = Z; _ = f"},
+	{"untyped: const W = 11 → float64", "const W = 11; var f float64 = W; _ = f"},
+	{"typed: explicit conversion", "const Z int = 11; var f = float64(Z); _ = f"},
+	// the same rule is why `time.Second * 2` works...
+	{"time.Second * 2", "d := time.Second * 2; _ = d"},
+	// ...and `time.Second * n` does not: n is a typed int VARIABLE
+	{"time.Second * n", "n := 2; d := time.Second * n; _ = d"},
+	{"time.Duration(n) * time.Second", "n := 2; d := time.Duration(n) * time.Second; _ = d"},
+}
+
+func typedUntyped() {
+	fmt.Println("🔹 4. Typed constants behave like variables of their type")
+
+	for _, sn := range typedSnippets {
+		fmt.Printf("  %-34s %s\n", sn.name, firstError(compile(sn.body)))
+	}
+
+	n := 2
+	fmt.Printf("  %-34s %v\n", "run it: time.Duration(n) * time.Second", time.Duration(n)*time.Second)
+	fmt.Println()
+}
+
+// ----------------------------
+// 5. iota patterns
+// ----------------------------
+func iotaPatterns() {
+	fmt.Println("🔹 5. iota: bit flags and byte sizes")
+
+	p := units.Read | units.Exec
+	fmt.Printf("  %-28s %v\n", "Read|Exec", p)
+	fmt.Printf("  %-28s %v (All = %v)\n", "All.Has(Write)", units.All.Has(units.Write), units.All)
+	fmt.Printf("  %-28s %v\n", "p &^ Exec clears a bit", p&^units.Exec)
+	fmt.Printf("  %-28s %v\n", "Perm(9): unknown bit", units.Perm(9))
+	fmt.Printf("  %-28s %T\n", "type of All", units.All)
+
+	fmt.Printf("  %-28s %v\n", "KB", float64(units.KB))
+	fmt.Printf("  %-28s %v\n", "ByteSize(1536)", units.ByteSize(1536))
+	fmt.Printf("  %-28s %v = %v\n", "YB (1 << 80, beyond int64)", float64(units.YB), units.YB)
+	fmt.Printf("  %-28s %v\n", "3.5 * GB", 3.5*units.GB)
+	fmt.Printf("  %-28s %v\n", "A,B C,D _,_ E,F", fmt.Sprint(units.A, units.B, units.C, units.D, units.E, units.F))
+	fmt.Println()
+}
+
+// ----------------------------
+// Type checking snippets
+// ----------------------------
+
+// compile type-checks body as the body of a function, with "time"
+// imported. The checker is 43.Compile_Fixtures' fixture.Check.
+func compile(body string) []fixture.Diagnostic {
+	src := "package p\n\nimport \"time\"\n\nvar _ = time.Second\n\nfunc f() {\n" + body + "\n}\n"
+	return fixture.Check("snippet.go", []byte(src), nil)
+}
+
+// constValue type-checks expr and returns the constant value the
+// compiler computed for it
+func constValue(expr string) (string, error) {
+	info := &types.Info{Defs: map[*ast.Ident]types.Object{}}
+	if diags := fixture.Check("const.go", []byte("package p\n\nconst c = "+expr+"\n"), info); len(diags) > 0 {
+		return "", errors.New(diags[0].Msg)
+	}
+	for id, obj := range info.Defs {
+		if c, ok := obj.(*types.Const); ok && id.Name == "c" {
+			return c.Val().ExactString(), nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a constant", expr)
+}
+
+// firstError is the message of the first error, or "compiles"
+func firstError(diags []fixture.Diagnostic) string {
+	if len(diags) == 0 {
+		return "compiles"
+	}
+	return diags[0].Msg
+}
+
+func main() {
+	precision()
+	defaults()
+	compileErrors()
+	typedUntyped()
+	iotaPatterns()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) A constant is a compile-time value. It has no address (&Z does not
+   compile) and takes no memory of its own: the compiler writes the
+   value straight into the instructions that use it (see it with
+   30.Asm_Viewer: `MOVL $11, ...`).
+
+2) Untyped constants are exact (at least 256 bits for integers): 1<<100
+   is fine, 0.1+0.2 == 0.3 is true. Limits apply only when the value is
+   given a type — by a declaration, an assignment or a conversion.
+
+3) Overflowing a type with a CONSTANT is a compile error; with a
+   VARIABLE it wraps silently at run time (int8 127 + 1 == -128).
+
+4) Typed constant (const Z int = 11): same rules as an int variable.
+   Untyped constant (const W = 11): fits any numeric type it is used
+   with. That is why `time.Second * 2` works and `time.Second * n`
+   needs time.Duration(n).
+
+5) iota is the line index inside a const block. Common patterns:
+       1 << iota           bit flags, combine with | and test with &
+       1 << (10 * iota)    KB, MB, GB ... (skip 0 with `_ = iota`)
+====================================================
+*/
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPrecision(t *testing.T) {
+	if Big>>98 != 4 {
+		t.Errorf("Big >> 98 = %v, want 4", Big>>98)
+	}
+	if float64(Big) != 1.2676506002282294e30 {
+		t.Errorf("float64(Big) = %v", float64(Big))
+	}
+	if 0.1+0.2 != 0.3 {
+		t.Error("const 0.1+0.2 != 0.3: constant arithmetic is not exact")
+	}
+	if x, y := 0.1, 0.2; x+y == 0.3 {
+		t.Error("float64 0.1+0.2 == 0.3: variables should be rounded")
+	}
+	const third = 1.0 / 3
+	if third*3 != 1 {
+		t.Errorf("const (1.0/3)*3 = %v, want 1", third*3)
+	}
+}
+
+func TestConstValue(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    string
+		wantErr bool
+	}{
+		{"1 << 100 >> 98", "4", false},
+		{"1.0 / 4", "1/4", false},
+		{`"go" + "pher"`, `"gopher"`, false},
+		{"1 / 0", "", true},
+	}
+	for _, tt := range tests {
+		got, err := constValue(tt.expr)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("constValue(%q) = %q, %v; want %q, error %v", tt.expr, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestDefaultTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any // passing to `any` forces a type
+		want string
+	}{
+		{"1", 1, "int"},
+		{"1.0", 1.0, "float64"},
+		{"'a'", 'a', "int32"}, // rune
+		{"1i", 1i, "complex128"},
+		{`"go"`, "go", "string"},
+		{"1 < 2", 1 < 2, "bool"},
+		{"1 + 1.5", 1 + 1.5, "float64"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf("%T", tt.v); got != tt.want {
+			t.Errorf("%s is %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSnippets(t *testing.T) {
+	// substring of the first compile error; "" = must compile
+	want := map[string]string{
+		"int8 overflow":                     "(overflows)",
+		"uint from -1":                      "(overflows)",
+		"Big used as an int":                "untyped int constant 1267650600228229401496703205376",
+		"Big >> 98 used as an int":          "",
+		"typed constant overflows its type": "as int8 value in constant declaration (overflows)",
+		"float constant beyond float64":     "(overflows)",
+		"constant division by zero":         "division by zero",
+		"assign to a constant":              "cannot assign to Z",
+		"address of a constant":             "cannot take address of Z",
+
+		"typed: const Z int → float64":    "cannot use Z (constant 11 of type int) as float64",
+		"untyped: const W = 11 → float64": "",
+		"typed: explicit conversion":      "",
+		"time.Second * 2":                 "",
+		"time.Second * n":                 "mismatched types time.Duration and int",
+		"time.Duration(n) * time.Second":  "",
+	}
+	for _, sn := range append(overflowSnippets, typedSnippets...) {
+		t.Run(sn.name, func(t *testing.T) {
+			w, ok := want[sn.name]
+			if !ok {
+				t.Fatal("no expectation for this snippet")
+			}
+			diags := compile(sn.body)
+			switch {
+			case w == "" && len(diags) > 0:
+				t.Errorf("got %q, want it to compile", diags[0].Msg)
+			case w != "" && len(diags) == 0:
+				t.Errorf("compiles, want an error containing %q", w)
+			case w != "" && !strings.Contains(diags[0].Msg, w):
+				t.Errorf("got %q, want it to contain %q", diags[0].Msg, w)
+			}
+		})
+	}
+}
+
+func TestVariableWraps(t *testing.T) {
+	var i int8 = 127
+	i++
+	if i != -128 {
+		t.Errorf("int8 127 + 1 = %d, want -128", i)
+	}
+}
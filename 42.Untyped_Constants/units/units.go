@@ -0,0 +1,102 @@
+// Package units holds the iota patterns used by the 42.Untyped_Constants
+// lesson: bit flags and byte sizes.
+package units
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ----------------------------
+// 1. Bit flags: 1 << iota
+// ----------------------------
+
+// Perm is a set of permissions; every constant is one bit
+type Perm uint8
+
+const (
+	Read  Perm = 1 << iota // 1 << 0 = 1
+	Write                  // 1 << 1 = 2
+	Exec                   // 1 << 2 = 4
+
+	None Perm = 0
+	All       = Read | Write | Exec // still a Perm: typed constants keep their type
+)
+
+// Has reports whether every bit of q is set in p
+func (p Perm) Has(q Perm) bool { return p&q == q }
+
+// String lists the set bits like "read|exec"; unknown bits are shown
+// as a number
+func (p Perm) String() string {
+	if p == None {
+		return "none"
+	}
+	var names []string
+	for _, f := range []struct {
+		bit  Perm
+		name string
+	}{{Read, "read"}, {Write, "write"}, {Exec, "exec"}} {
+		if p.Has(f.bit) {
+			names = append(names, f.name)
+			p &^= f.bit // clear the bit
+		}
+	}
+	if p != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint8(p)))
+	}
+	return strings.Join(names, "|")
+}
+
+// ----------------------------
+// 2. Byte sizes: skip the zero value, then 1 << (10 * iota)
+// ----------------------------
+
+// ByteSize is a number of bytes
+type ByteSize float64
+
+const (
+	_           = iota             // 0: ignored
+	KB ByteSize = 1 << (10 * iota) // 1 << 10
+	MB                             // 1 << 20
+	GB                             // 1 << 30
+	TB                             // 1 << 40
+	PB                             // 1 << 50
+	EB                             // 1 << 60
+	ZB                             // 1 << 70: larger than any int64, fine for a float64
+	YB                             // 1 << 80
+)
+
+// String picks the largest unit that keeps the number ≥ 1
+func (b ByteSize) String() string {
+	switch {
+	case b >= YB:
+		return fmt.Sprintf("%.2fYB", b/YB)
+	case b >= ZB:
+		return fmt.Sprintf("%.2fZB", b/ZB)
+	case b >= EB:
+		return fmt.Sprintf("%.2fEB", b/EB)
+	case b >= PB:
+		return fmt.Sprintf("%.2fPB", b/PB)
+	case b >= TB:
+		return fmt.Sprintf("%.2fTB", b/TB)
+	case b >= GB:
+		return fmt.Sprintf("%.2fGB", b/GB)
+	case b >= MB:
+		return fmt.Sprintf("%.2fMB", b/MB)
+	case b >= KB:
+		return fmt.Sprintf("%.2fKB", b/KB)
+	}
+	return fmt.Sprintf("%.2fB", float64(b))
+}
+
+// ----------------------------
+// 3. iota restarts in every const block and counts lines, not names
+// ----------------------------
+
+const (
+	A, B = iota, iota * 10 // 0, 0
+	C, D                   // 1, 10
+	_, _                   // 2: skipped
+	E, F                   // 3, 30
+)
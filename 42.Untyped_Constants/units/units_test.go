@@ -0,0 +1,58 @@
+package units
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPerm(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Perm
+		want string
+	}{
+		{"Read|Exec", Read | Exec, "read|exec"},
+		{"All", All, "read|write|exec"},
+		{"None", None, "none"},
+		{"(Read|Exec) &^ Exec clears a bit", (Read | Exec) &^ Exec, "read"},
+		{"Perm(9): unknown bit shown as number", Perm(9), "read|0x8"},
+	}
+	for _, tt := range tests {
+		if got := tt.p.String(); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if !All.Has(Write) || Read.Has(Read|Write) {
+		t.Error("Has must test every bit of its argument")
+	}
+	if got := fmt.Sprintf("%T", All); got != "units.Perm" {
+		t.Errorf("All is %s, want units.Perm: typed constants keep their type", got)
+	}
+}
+
+func TestByteSize(t *testing.T) {
+	if KB != 1024 {
+		t.Errorf("KB = %v, want 1024", float64(KB))
+	}
+	tests := []struct {
+		b    ByteSize
+		want string
+	}{
+		{512, "512.00B"},
+		{1536, "1.50KB"},
+		{3.5 * GB, "3.50GB"},
+		{YB, "1.00YB"}, // 1 << 80: beyond int64
+	}
+	for _, tt := range tests {
+		if got := tt.b.String(); got != tt.want {
+			t.Errorf("ByteSize(%v) = %q, want %q", float64(tt.b), got, tt.want)
+		}
+	}
+}
+
+func TestIotaCountsLines(t *testing.T) {
+	got := fmt.Sprint(A, B, C, D, E, F)
+	if want := "0 0 1 10 3 30"; got != want {
+		t.Errorf("A,B C,D _,_ E,F = %s, want %s", got, want)
+	}
+}
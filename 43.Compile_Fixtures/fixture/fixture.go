@@ -0,0 +1,212 @@
+// Package fixture type-checks Go files that are SUPPOSED to fail, and
+// compares their errors with the expectations written inside them.
+//
+// A fixture is a complete, single-file package. Every line that must not
+// compile carries a comment with a regular expression, the convention of
+// the Go repository's own test/ directory:
+//
+//	a = true // ERROR "cannot use true .* as int value"
+//
+// A fixture passes when every ERROR pattern matches an error reported on
+// its line, and no other line has an error.
+//
+// The lessons keep invalid code as comments (1.Variables' `a = true`,
+// 17.Pointers' `printNumbers(arr)`, 4.Scope's `add(z, a)`), and a comment
+// can be wrong without anybody noticing. Each of those snippets is a
+// fixture in ./testdata, checked by TestFixtures:
+//
+//	go test ./43.Compile_Fixtures/...      all fixtures
+//	go test -v ./43.Compile_Fixtures/...   also log every matched error
+//
+// The go command ignores testdata directories, so `go build ./...` and
+// `go vet ./...` never see the broken files. go/types words a few errors
+// differently from the compiler (see testdata/variables_short_decl.go):
+// the patterns follow go/types. To add a fixture, copy the invalid
+// snippet into a small main package in ./testdata and mark the failing
+// line with // ERROR "pattern"; escape ( ) [ ] * . in the pattern.
+package fixture
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Expectation is one ERROR pattern
+type Expectation struct {
+	Line    int
+	Pattern *regexp.Regexp
+}
+
+// Diagnostic is one error from the parser or the type checker
+type Diagnostic struct {
+	Pos token.Position
+	Msg string
+}
+
+func (d Diagnostic) String() string { return fmt.Sprintf("%d:%d: %s", d.Pos.Line, d.Pos.Column, d.Msg) }
+
+// Match pairs an expectation with the error it matched
+type Match struct {
+	Expectation
+	Diagnostic
+}
+
+// Result is the outcome of checking one fixture
+type Result struct {
+	File       string
+	Matched    []Match
+	Missing    []Expectation // patterns no error matched
+	Unexpected []Diagnostic  // errors no pattern asked for
+}
+
+// OK reports whether the fixture failed exactly as expected
+func (r Result) OK() bool {
+	return len(r.Missing) == 0 && len(r.Unexpected) == 0 && len(r.Matched) > 0
+}
+
+// errorComment finds `// ERROR "..."` and captures the quoted patterns.
+// As in the Go repository, the text between the quotes is the regular
+// expression as is: `\(` means a literal parenthesis, only `\"` is
+// turned into a quote.
+var (
+	errorComment = regexp.MustCompile(`//\s*ERROR\b\s*(.*)$`)
+	quoted       = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+)
+
+// CheckDir checks every .go file in dir, sorted by name
+func CheckDir(dir string) ([]Result, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no .go files in %s", dir)
+	}
+	sort.Strings(paths)
+
+	var results []Result
+	for _, path := range paths {
+		r, err := CheckFile(path)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// CheckFile reads and checks one fixture
+func CheckFile(path string) (Result, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return Result{}, err
+	}
+	return CheckSource(filepath.Base(path), src)
+}
+
+// CheckSource checks a fixture held in memory. The error is only for a
+// broken fixture (a bad pattern, no patterns at all), never for the
+// compile errors the fixture is about.
+func CheckSource(name string, src []byte) (Result, error) {
+	expects, err := expectations(name, src)
+	if err != nil {
+		return Result{}, err
+	}
+	if len(expects) == 0 {
+		return Result{}, fmt.Errorf("%s: no // ERROR comments: a fixture must fail somewhere", name)
+	}
+
+	r := Result{File: name}
+	r.Matched, r.Missing, r.Unexpected = compare(expects, Check(name, src, nil))
+	return r, nil
+}
+
+// expectations reads every ERROR comment of src
+func expectations(name string, src []byte) ([]Expectation, error) {
+	var expects []Expectation
+	for i, line := range strings.Split(string(src), "\n") {
+		m := errorComment.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		patterns := quoted.FindAllStringSubmatch(m[1], -1)
+		if len(patterns) == 0 {
+			return nil, fmt.Errorf("%s:%d: ERROR needs a quoted pattern", name, i+1)
+		}
+		for _, q := range patterns {
+			re, err := regexp.Compile(strings.ReplaceAll(q[1], `\"`, `"`))
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %v", name, i+1, err)
+			}
+			expects = append(expects, Expectation{Line: i + 1, Pattern: re})
+		}
+	}
+	return expects, nil
+}
+
+// Check parses and type-checks src, a single-file package, and returns
+// ALL its errors. Type checking is skipped when the file does not parse:
+// like the compiler, syntax errors come first. A non-nil info is filled
+// by the type checker (constant values, types, definitions).
+func Check(name string, src []byte, info *types.Info) []Diagnostic {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, parser.AllErrors)
+	if err != nil {
+		var list scanner.ErrorList
+		if !errors.As(err, &list) {
+			return []Diagnostic{{Msg: err.Error()}}
+		}
+		var diags []Diagnostic
+		for _, e := range list {
+			diags = append(diags, Diagnostic{Pos: e.Pos, Msg: e.Msg})
+		}
+		return diags
+	}
+
+	var diags []Diagnostic
+	conf := types.Config{
+		Importer: importer.Default(),
+		Error: func(err error) { // called for every error, not just the first
+			if te, ok := err.(types.Error); ok {
+				diags = append(diags, Diagnostic{Pos: fset.Position(te.Pos), Msg: te.Msg})
+			}
+		},
+	}
+	conf.Check(file.Name.Name, fset, []*ast.File{file}, info)
+	return diags
+}
+
+// compare pairs every expectation with an unused error on its line
+func compare(expects []Expectation, diags []Diagnostic) (matched []Match, missing []Expectation, unexpected []Diagnostic) {
+	used := make([]bool, len(diags))
+	for _, e := range expects {
+		found := false
+		for i, d := range diags {
+			if !used[i] && d.Pos.Line == e.Line && e.Pattern.MatchString(d.Msg) {
+				used[i], found = true, true
+				matched = append(matched, Match{e, d})
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, e)
+		}
+	}
+	for i, d := range diags {
+		if !used[i] {
+			unexpected = append(unexpected, d)
+		}
+	}
+	return matched, missing, unexpected
+}
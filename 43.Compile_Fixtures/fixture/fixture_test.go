@@ -0,0 +1,93 @@
+package fixture
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestFixtures checks every fixture in testdata. Run with -v to see the
+// errors each one matched.
+func TestFixtures(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no fixtures in testdata")
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			r, err := CheckFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range r.Missing {
+				t.Errorf("line %d: no error matches %q", m.Line, m.Pattern)
+			}
+			for _, d := range r.Unexpected {
+				t.Errorf("unexpected error %s", d)
+			}
+			for _, m := range r.Matched {
+				t.Logf("%s", m.Diagnostic)
+			}
+		})
+	}
+}
+
+// TestCheckSource shows that a wrong expectation is reported, not ignored
+func TestCheckSource(t *testing.T) {
+	const header = "package main\n\nfunc main() {\n"
+	tests := []struct {
+		name           string
+		body           string
+		wantMissing    int
+		wantUnexpected int
+	}{
+		{"right pattern", "\tx := 1\n\tx = \"s\" // ERROR \"cannot use .* as int value\"\n\t_ = x\n}\n", 0, 0},
+		{"wrong pattern", "\tx := 1\n\tx = \"s\" // ERROR \"undefined\"\n\t_ = x\n}\n", 1, 1},
+		{"error on another line", "\tx := 1 // ERROR \"cannot use\"\n\tx = \"s\"\n\t_ = x\n}\n", 1, 1},
+		{"snippet compiles after all", "\tx := 1\n\tx = 2 // ERROR \"cannot use\"\n\t_ = x\n}\n", 1, 0},
+		{"second, unannounced error", "\tx := 1\n\tx = \"s\" // ERROR \"cannot use\"\n\t_ = x\n\ty := 2\n}\n", 0, 1}, // y: declared and not used
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := CheckSource(tt.name+".go", []byte(header+tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(r.Missing) != tt.wantMissing || len(r.Unexpected) != tt.wantUnexpected {
+				t.Errorf("missing=%d unexpected=%d, want %d %d", len(r.Missing), len(r.Unexpected), tt.wantMissing, tt.wantUnexpected)
+			}
+			if wantOK := tt.wantMissing == 0 && tt.wantUnexpected == 0; r.OK() != wantOK {
+				t.Errorf("OK() = %v, want %v", r.OK(), wantOK)
+			}
+		})
+	}
+}
+
+func TestBrokenFixtures(t *testing.T) {
+	const header = "package main\n\nfunc main() {\n"
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"no ERROR comments", "}\n",
+			"broken.go: no // ERROR comments: a fixture must fail somewhere"},
+		{"ERROR without a pattern", "\tx := \"s\" // ERROR\n\t_ = x\n}\n",
+			"broken.go:4: ERROR needs a quoted pattern"},
+		{"ERROR with an unquoted pattern", "\tx := \"s\" // ERROR declared and not used\n}\n",
+			"broken.go:4: ERROR needs a quoted pattern"},
+		{"invalid regular expression", "\tx := \"s\" // ERROR \"(\"\n\t_ = x\n}\n",
+			"broken.go:4: error parsing regexp: missing closing ): `(`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CheckSource("broken.go", []byte(header+tt.body))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// 44.Array_Lab: the length is part of an array's type, and only
+// comparable types can be map keys
+package main
+
+import "fmt"
+
+func main() {
+	a := [2]int{1, 2}
+	b := [3]int{1, 2, 3}
+	fmt.Println(a == b) // ERROR "mismatched types \[2\]int and \[3\]int"
+	a = b               // ERROR "cannot use b \(variable of type \[3\]int\) as \[2\]int value"
+
+	s := []int{1, 2}
+	c := [2]int(s) // fine: checked at run time
+	fmt.Println(c)
+
+	m := map[[]int]string{} // ERROR "invalid map key type \[\]int"
+	fmt.Println(m)
+
+	n := 3
+	var d [n]int // ERROR "invalid array length n"
+	fmt.Println(d, n)
+}
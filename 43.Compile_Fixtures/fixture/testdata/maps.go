@@ -0,0 +1,23 @@
+// 46.Maps: a map element is not addressable, so a field of a struct
+// stored BY VALUE cannot be assigned in place
+package main
+
+import "fmt"
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func main() {
+	byValue := map[string]user{"sadik": {"Sadik", 25}}
+	byValue["sadik"].Age = 26 // ERROR "cannot assign to struct field byValue\[\"sadik\"\]\.Age in map"
+	p := &byValue["sadik"]    // ERROR "cannot take address of byValue\[\"sadik\"\]"
+
+	byPointer := map[string]*user{"sadik": {"Sadik", 25}}
+	byPointer["sadik"].Age = 26 // fine: the element is a pointer
+	fmt.Println(byValue, byPointer, p)
+
+	bad := map[[]string]int{} // ERROR "invalid map key type \[\]string"
+	fmt.Println(bad)
+}
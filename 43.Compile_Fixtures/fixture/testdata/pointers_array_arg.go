@@ -0,0 +1,20 @@
+// 17.Pointers: printNumbers wants the ADDRESS of an array
+//
+//	// printNumbers(arr)  // ❌ invalid, expects pointer
+package main
+
+import "fmt"
+
+func printNumbers(numbers *[3]int) {
+	fmt.Println(*numbers)
+}
+
+func main() {
+	arr := [3]int{1, 2, 3}
+	printNumbers(arr) // ERROR "cannot use arr \(variable of type \[3\]int\) as \*\[3\]int value in argument to printNumbers"
+	printNumbers(&arr)
+
+	// a pointer to an array of another length is another type
+	short := [2]int{1, 2}
+	printNumbers(&short) // ERROR "cannot use &short .* as \*\[3\]int value"
+}
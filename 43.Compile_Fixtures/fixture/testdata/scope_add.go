@@ -0,0 +1,21 @@
+// 4.Scope: z is declared inside add, so it only exists inside add
+//
+//	// add (z, a) // Doesnt work as z onky lives within add
+package main
+
+import "fmt"
+
+var (
+	a = 30
+	b = 20
+)
+
+func add(x int, y int) {
+	z := x + y
+	fmt.Println(z)
+}
+
+func main() {
+	add(a, b)
+	add(z, a) // ERROR "undefined: z"
+}
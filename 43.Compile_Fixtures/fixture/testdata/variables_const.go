@@ -0,0 +1,13 @@
+// 1.Variables: a constant's value must be assigned at declaration, and
+// cannot be changed later
+package main
+
+import "fmt"
+
+const Y int // ERROR "missing init expr for Y"
+
+func main() {
+	const Z int = 11
+	Z = 12 // ERROR "cannot assign to Z"
+	fmt.Println(Y, Z)
+}
@@ -0,0 +1,12 @@
+// 1.Variables: a := 10 fixes a's type for good
+//
+//	a = true  // invalid → 'a' is already an int
+package main
+
+import "fmt"
+
+func main() {
+	a := 10
+	a = true // ERROR "cannot use true \(untyped bool constant\) as int value in assignment"
+	fmt.Println(a)
+}
@@ -0,0 +1,13 @@
+// 1.Variables: ":=" can ONLY be used INSIDE functions
+//
+// go/parser words it differently from the compiler, which says
+// "non-declaration statement outside function body"
+package main
+
+import "fmt"
+
+b := "Hello Go!" // ERROR "expected declaration, found b"
+
+func main() {
+	fmt.Println(b)
+}
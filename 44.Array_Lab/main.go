@@ -0,0 +1,285 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"go_projects/44.Array_Lab/matrix"
+)
+
+/*
+====================================================
+🔹 ARRAYS: VALUES WITH THEIR LENGTH IN THE TYPE
+====================================================
+
+16.Array declares [2]int and [3]string and prints them. What makes an
+array different from a slice:
+
+   1. an array is a VALUE: b := a copies every element
+   2. arrays compare with == (same type = same length and element type)
+   3. ...so they can be map keys: map[[2]int]string
+   4. [3][3]int is a real grid in one block of memory (./matrix)
+   5. [...]T{...} lets the compiler count the length
+   6. slice → array: [N]T(s) copies, (*[N]T)(s) shares, both PANIC if
+      len(s) < N
+
+The lines that do not compile ([2]int == [3]int, a slice as a map key)
+are fixtures in 43.Compile_Fixtures/fixture/testdata/arrays.go.
+
+Each section prints what happens; the expected results are tests in
+main_test.go and matrix/matrix_test.go.
+
+Run it:
+    go run ./44.Array_Lab
+    go test ./44.Array_Lab/...
+====================================================
+*/
+
+// show prints one observation
+func show(name, got string) {
+	fmt.Printf("  %-40s %s\n", name, got)
+}
+
+// ----------------------------
+// 1. Value semantics
+// ----------------------------
+
+// zeroFirst gets a COPY of the array
+func zeroFirst(a [3]int) { a[0] = 0 }
+
+// zeroFirstPtr gets the address: the caller's array changes
+func zeroFirstPtr(a *[3]int) { a[0] = 0 } // a[0] is (*a)[0]
+
+func valueSemantics() {
+	fmt.Println("🔹 1. An array is a value: assignment and calls copy it")
+
+	a := [3]int{1, 2, 3}
+	b := a // 3 ints copied
+	b[0] = 99
+	show("b := a; b[0] = 99 leaves a alone", fmt.Sprint("a=", a, " b=", b))
+
+	zeroFirst(a)
+	show("func(a [3]int) changes its copy", fmt.Sprint(a))
+	zeroFirstPtr(&a)
+	show("func(a *[3]int) changes the original", fmt.Sprint(a))
+
+	// a slice header is copied too, but it points at the same elements
+	s := []int{1, 2, 3}
+	t := s
+	t[0] = 99
+	show("slice: t := s; t[0] = 99 changes s", fmt.Sprint("s=", s))
+
+	// for range over an ARRAY iterates over a copy of it
+	arr := [3]int{1, 2, 3}
+	var seen []int
+	for i, v := range arr {
+		if i == 0 {
+			arr[2] = 100 // too late: range copied arr before the loop
+		}
+		seen = append(seen, v)
+	}
+	show("range arr sees the array as it was", fmt.Sprint("seen=", seen, " arr=", arr))
+
+	// an array inside a struct is copied with the struct
+	type player struct {
+		Name   string
+		Scores [3]int
+	}
+	p1 := player{"Sadik", [3]int{10, 20, 30}}
+	p2 := p1
+	p2.Scores[0] = 0
+	show("struct copy copies its array field", fmt.Sprint("p1=", p1.Scores, " p2=", p2.Scores))
+	fmt.Println()
+}
+
+// ----------------------------
+// 2-3. Comparison and map keys
+// ----------------------------
+
+// point is a grid coordinate: comparable, so usable as a map key
+type point [2]int
+
+func comparison() {
+	fmt.Println("🔹 2-3. == compares every element; arrays can be map keys")
+
+	a := [3]string{"Hello", "Mallow", "Shallow"} // 16.Array's arr3
+	b := [3]string{"Hello", "Mallow", "Shallow"}
+	c := b
+	c[2] = "Fallow"
+	show("equal elements → ==", fmt.Sprint(a == b))
+	show("one element differs → !=", fmt.Sprint(a == c))
+
+	var zero [2]int
+	show("zero value is all zeros, == [2]int{}", fmt.Sprint(zero))
+
+	// visit cells of a grid; every revisit finds the same key
+	visits := map[point]int{}
+	path := []point{{0, 0}, {0, 1}, {1, 1}, {0, 1}, {0, 0}, {0, 1}}
+	for _, p := range path {
+		visits[p]++
+	}
+	show("map[point]int counts visits", fmt.Sprint(visits))
+
+	// a key built later with the same values finds the entry
+	key := point{}
+	key[0], key[1] = 1, 1
+	show("new array, same values → same key", fmt.Sprint("visits[{1 1}]=", visits[key]))
+
+	// strings.Fields returns a slice; to use it as a key copy it into an array
+	words := strings.Fields("go is fun")
+	seen := map[[3]string]bool{[3]string(words): true}
+	show("[3]string(slice) as a map key", fmt.Sprint(seen))
+	fmt.Println()
+}
+
+// ----------------------------
+// 4. Multi-dimensional arrays
+// ----------------------------
+func grid() {
+	fmt.Println("🔹 4. [3][3]int: a grid in one block, with methods")
+
+	var m matrix.Mat3
+	for i := range 3 {
+		for j := range 3 {
+			m.Set(i, j, i*3+j+1)
+		}
+	}
+	show("Set through a pointer receiver", fmt.Sprint(m.Row(2)))
+	show("Col(0)", fmt.Sprint(m.Col(0)))
+
+	t := m.Transpose()
+	show("Transpose returns a new matrix", fmt.Sprint("t.Row(0)=", t.Row(0), " m[0][1]=", m[0][1]))
+	show("Transpose twice == original", fmt.Sprint(t.Transpose() == m)) // whole matrices compared with ==
+	show("m × Identity == m", fmt.Sprint(m.Mul(matrix.Identity()) == m))
+
+	fmt.Println("  m:")
+	fmt.Println(indent(m.String()))
+	fmt.Println("  m × m:")
+	fmt.Println(indent(m.Mul(m).String()))
+
+	// rows are arrays too: row := m[1] copies it
+	row := m[1]
+	row[0] = 0
+	show("row := m[1] is a copy", fmt.Sprint("m[1]=", m[1], " row=", row))
+	fmt.Println()
+}
+
+// ----------------------------
+// 5. [...] length inference
+// ----------------------------
+func inference() {
+	fmt.Println("🔹 5. [...]T{...}: the compiler counts")
+
+	days := [...]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+	show("[...]string with 7 values", fmt.Sprintf("%T", days))
+
+	// index keys: the length is the highest index + 1
+	sparse := [...]int{9: 1}
+	show("[...]int{9: 1}", fmt.Sprint(sparse))
+
+	const (
+		Red = iota
+		Green
+		Blue
+	)
+	names := [...]string{Blue: "blue", Red: "red", Green: "green"}
+	show("keyed by iota constants, any order", fmt.Sprint(names))
+
+	// [...] is only for literals: the type is still a fixed [10]int
+	show("[...] is still an array type", fmt.Sprintf("%T", sparse))
+	fmt.Println()
+}
+
+// ----------------------------
+// 6. Slices ↔ arrays
+// ----------------------------
+
+// toArray3 converts and returns the panic message, if any
+func toArray3(s []int) (arr [3]int, panicMsg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicMsg = fmt.Sprint(r)
+		}
+	}()
+	return [3]int(s), ""
+}
+
+func conversions() {
+	fmt.Println("🔹 6. Slice ↔ array conversions")
+
+	arr := [4]int{1, 2, 3, 4}
+	s := arr[:] // a slice that shares arr's memory
+	s[0] = 100
+	show("arr[:] shares the array", fmt.Sprint(arr))
+
+	src := []int{1, 2, 3, 4, 5}
+	copied := [3]int(src) // Go 1.20: copies the first 3 elements
+	copied[0] = 0
+	show("[3]int(s) copies", fmt.Sprint("copied=", copied, " src=", src))
+
+	shared := (*[3]int)(src) // Go 1.17: points into src
+	shared[0] = 0
+	show("(*[3]int)(s) shares", fmt.Sprint("src=", src))
+
+	// the length of the array must fit in len(s), not cap(s)
+	tests := []struct {
+		name string
+		s    []int
+	}{
+		{"len 3 → [3]int", []int{1, 2, 3}},
+		{"len 5 → [3]int (extra ignored)", []int{1, 2, 3, 4, 5}},
+		{"len 2 → [3]int", []int{1, 2}},
+		{"len 2, cap 10 → [3]int", make([]int, 2, 10)},
+		{"nil → [3]int", nil},
+	}
+	for _, tt := range tests {
+		got, msg := toArray3(tt.s)
+		if msg != "" {
+			show(tt.name, "panic: "+msg)
+			continue
+		}
+		show(tt.name, fmt.Sprint(got))
+	}
+
+	// converting to [0]T never panics, and a nil slice → nil *[0]T
+	var empty []int
+	show("(*[0]int)(nil slice) == nil", fmt.Sprint((*[0]int)(empty) == nil))
+	fmt.Println()
+}
+
+// indent prints a multi-line value under the show lines
+func indent(s string) string {
+	return "        " + strings.ReplaceAll(s, "\n", "\n        ")
+}
+
+func main() {
+	valueSemantics()
+	comparison()
+	grid()
+	inference()
+	conversions()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) The length is part of the type: [2]int and [3]int are different
+   types, cannot be compared or assigned to each other.
+
+2) Arrays are values. b := a, passing a to a function, `range a` and
+   copying a struct that holds one all copy every element. Use a
+   pointer (*[N]T) or a slice (a[:]) to share instead; 27.Copy_Cost
+   measures when the copy starts to matter.
+
+3) Arrays of comparable elements are comparable, hence valid map keys
+   (coordinates, fixed-size tuples). Slices are neither.
+
+4) [R][C]T is one contiguous block; a method with a value receiver
+   works on a copy, so Transpose can return its modified receiver.
+
+5) [N]T(s) copies, (*[N]T)(s) aliases. Both need len(s) >= N, checked
+   at run time: a short slice panics, whatever its capacity.
+====================================================
+*/
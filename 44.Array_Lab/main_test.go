@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValueSemantics(t *testing.T) {
+	a := [3]int{1, 2, 3}
+	b := a
+	b[0] = 99
+	if a[0] != 1 {
+		t.Errorf("b := a; b[0] = 99 changed a: %v", a)
+	}
+
+	zeroFirst(a)
+	if a[0] != 1 {
+		t.Errorf("zeroFirst changed the caller's array: %v", a)
+	}
+	zeroFirstPtr(&a)
+	if a[0] != 0 {
+		t.Errorf("zeroFirstPtr did not change the caller's array: %v", a)
+	}
+
+	// range over an ARRAY iterates over a copy of it
+	arr := [3]int{1, 2, 3}
+	var seen []int
+	for i, v := range arr {
+		if i == 0 {
+			arr[2] = 100
+		}
+		seen = append(seen, v)
+	}
+	if seen[2] != 3 || arr[2] != 100 {
+		t.Errorf("seen=%v arr=%v: range should see the array as it was", seen, arr)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	visits := map[point]int{}
+	for _, p := range []point{{0, 0}, {0, 1}, {1, 1}, {0, 1}, {0, 0}, {0, 1}} {
+		visits[p]++
+	}
+	if visits[point{0, 1}] != 3 || len(visits) != 3 {
+		t.Errorf("visits = %v, want {0 1} three times, 3 keys", visits)
+	}
+
+	// a key built later with the same values finds the entry
+	var key point
+	key[0], key[1] = 1, 1
+	if visits[key] != 1 {
+		t.Errorf("visits[%v] = %d, want 1", key, visits[key])
+	}
+
+	words := strings.Fields("go is fun")
+	seen := map[[3]string]bool{[3]string(words): true}
+	if !seen[[3]string{"go", "is", "fun"}] {
+		t.Errorf("[3]string(words) is not found by an equal array: %v", seen)
+	}
+}
+
+func TestInference(t *testing.T) {
+	sparse := [...]int{9: 1}
+	if got := fmt.Sprintf("%T", sparse); got != "[10]int" {
+		t.Errorf("[...]int{9: 1} is %s, want [10]int", got)
+	}
+
+	const (
+		Red = iota
+		Green
+		Blue
+	)
+	names := [...]string{Blue: "blue", Red: "red", Green: "green"}
+	if names != [3]string{"red", "green", "blue"} {
+		t.Errorf("keyed by iota constants: %v", names)
+	}
+}
+
+func TestToArray3(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         []int
+		wantPanic bool
+	}{
+		{"len 3", []int{1, 2, 3}, false},
+		{"len 5, extra ignored", []int{1, 2, 3, 4, 5}, false},
+		{"len 2", []int{1, 2}, true},
+		{"len 2, cap 10", make([]int, 2, 10), true}, // len counts, not cap
+		{"nil", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, msg := toArray3(tt.s)
+			if tt.wantPanic {
+				if !strings.Contains(msg, "cannot convert slice with length") {
+					t.Errorf("panic = %q, want a conversion panic", msg)
+				}
+				return
+			}
+			if msg != "" || got != [3]int{1, 2, 3} {
+				t.Errorf("got %v, panic %q; want [1 2 3]", got, msg)
+			}
+		})
+	}
+}
+
+func TestConversions(t *testing.T) {
+	src := []int{1, 2, 3, 4, 5}
+	copied := [3]int(src)
+	copied[0] = 0
+	if src[0] != 1 {
+		t.Error("[3]int(s) shares memory with s")
+	}
+
+	shared := (*[3]int)(src)
+	shared[0] = 0
+	if src[0] != 0 {
+		t.Error("(*[3]int)(s) does not share memory with s")
+	}
+
+	var empty []int
+	if (*[0]int)(empty) != nil {
+		t.Error("(*[0]int)(nil slice) != nil")
+	}
+}
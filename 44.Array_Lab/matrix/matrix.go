@@ -0,0 +1,70 @@
+// Package matrix is the multi-dimensional array example of the
+// 44.Array_Lab lesson: a 3x3 matrix that is a plain [3][3]int.
+package matrix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mat3 is an array of 3 rows of 3 ints. It is a VALUE: assigning it or
+// passing it copies all 9 ints (72 bytes), which is why the methods that
+// compute something return a new Mat3 instead of a pointer.
+type Mat3 [3][3]int
+
+// Identity returns the matrix with 1 on the diagonal
+func Identity() Mat3 {
+	var m Mat3
+	for i := range m {
+		m[i][i] = 1
+	}
+	return m
+}
+
+// Set changes one cell. It needs a pointer receiver: with a value
+// receiver it would change a copy.
+func (m *Mat3) Set(row, col, v int) { m[row][col] = v }
+
+// Row returns row i; the result is a copy of the [3]int
+func (m Mat3) Row(i int) [3]int { return m[i] }
+
+// Col returns column j
+func (m Mat3) Col(j int) [3]int {
+	return [3]int{m[0][j], m[1][j], m[2][j]}
+}
+
+// Transpose swaps rows and columns. m is a copy, so it can be changed
+// freely without touching the caller's matrix.
+func (m Mat3) Transpose() Mat3 {
+	for i := range 3 {
+		for j := i + 1; j < 3; j++ {
+			m[i][j], m[j][i] = m[j][i], m[i][j]
+		}
+	}
+	return m
+}
+
+// Mul returns m × o
+func (m Mat3) Mul(o Mat3) Mat3 {
+	var out Mat3
+	for i := range 3 {
+		for j := range 3 {
+			for k := range 3 {
+				out[i][j] += m[i][k] * o[k][j]
+			}
+		}
+	}
+	return out
+}
+
+// String prints one row per line
+func (m Mat3) String() string {
+	var b strings.Builder
+	for i, row := range m {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%3d %3d %3d", row[0], row[1], row[2])
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package matrix
+
+import "testing"
+
+// count is 1 2 3 / 4 5 6 / 7 8 9, filled through Set
+func count() Mat3 {
+	var m Mat3
+	for i := range 3 {
+		for j := range 3 {
+			m.Set(i, j, i*3+j+1)
+		}
+	}
+	return m
+}
+
+func TestSetRowCol(t *testing.T) {
+	m := count()
+	if m[2][2] != 9 {
+		t.Errorf("Set through a pointer receiver: m[2][2] = %d, want 9", m[2][2])
+	}
+	if got := m.Row(2); got != [3]int{7, 8, 9} {
+		t.Errorf("Row(2) = %v", got)
+	}
+	if got := m.Col(0); got != [3]int{1, 4, 7} {
+		t.Errorf("Col(0) = %v", got)
+	}
+
+	row := m.Row(1)
+	row[0] = 0
+	if m[1][0] != 4 {
+		t.Error("changing the result of Row changed the matrix")
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	m := count()
+	tr := m.Transpose()
+	if tr.Row(0) != m.Col(0) {
+		t.Errorf("Transpose().Row(0) = %v, want %v", tr.Row(0), m.Col(0))
+	}
+	if m[0][1] != 2 {
+		t.Errorf("Transpose changed its receiver: m[0][1] = %d", m[0][1])
+	}
+	if tr.Transpose() != m {
+		t.Error("transposing twice does not give the original")
+	}
+}
+
+func TestMul(t *testing.T) {
+	m := count()
+	if m.Mul(Identity()) != m {
+		t.Errorf("m × I = %v, want m", m.Mul(Identity()))
+	}
+	want := Mat3{{30, 36, 42}, {66, 81, 96}, {102, 126, 150}}
+	if got := m.Mul(m); got != want {
+		t.Errorf("m × m = %v, want %v", got, want)
+	}
+}
+
+func TestString(t *testing.T) {
+	want := "  1   2   3\n  4   5   6\n  7   8   9"
+	if got := count().String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
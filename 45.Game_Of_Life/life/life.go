@@ -0,0 +1,277 @@
+// Package life is Conway's Game of Life on three grid layouts, for the
+// 45.Game_Of_Life lesson:
+//
+//	ArrayBoard  [Size][Size]bool   one block, size fixed at compile time
+//	SliceBoard  [][]bool           one slice header per row, rows anywhere
+//	FlatBoard   []bool, W*H cells  one block, size chosen at run time
+//
+// The board wraps around: the right neighbour of the last column is the
+// first column (a torus), so every cell has 8 neighbours.
+package life
+
+import "strings"
+
+// Size is the side of an ArrayBoard. An array length must be a constant.
+const Size = 256
+
+// Board is what rendering and pattern placement need. Step functions
+// use the concrete types: an interface call per cell would hide the
+// differences between the layouts.
+type Board interface {
+	Width() int
+	Height() int
+	Alive(x, y int) bool
+	Set(x, y int, alive bool)
+}
+
+// wrap maps -1 to n-1 and n to 0
+func wrap(i, n int) int { return (i + n) % n }
+
+// rule is Conway's rule: a live cell survives with 2 or 3 neighbours,
+// a dead cell comes alive with exactly 3
+func rule(alive bool, n int) bool {
+	return n == 3 || alive && n == 2
+}
+
+// ----------------------------
+// 1. Fixed-size array
+// ----------------------------
+
+// ArrayBoard is a value: `b2 := b1` copies all Size*Size cells. Use it
+// through a pointer.
+type ArrayBoard [Size][Size]bool
+
+func (b *ArrayBoard) Width() int               { return Size }
+func (b *ArrayBoard) Height() int              { return Size }
+func (b *ArrayBoard) Alive(x, y int) bool      { return b[wrap(y, Size)][wrap(x, Size)] }
+func (b *ArrayBoard) Set(x, y int, alive bool) { b[wrap(y, Size)][wrap(x, Size)] = alive }
+
+// StepArray writes the next generation of src into dst. dst and src
+// must be different boards.
+func StepArray(dst, src *ArrayBoard) {
+	for y := range Size {
+		up, down := wrap(y-1, Size), wrap(y+1, Size)
+		for x := range Size {
+			left, right := wrap(x-1, Size), wrap(x+1, Size)
+			n := 0
+			for _, c := range [8]bool{
+				src[up][left], src[up][x], src[up][right],
+				src[y][left], src[y][right],
+				src[down][left], src[down][x], src[down][right],
+			} {
+				if c {
+					n++
+				}
+			}
+			dst[y][x] = rule(src[y][x], n)
+		}
+	}
+}
+
+// ----------------------------
+// 2. Slice of slices
+// ----------------------------
+
+// SliceBoard is a slice of rows. Every row is its own allocation, and
+// nothing stops two rows from being the SAME slice (see SharedRows).
+type SliceBoard [][]bool
+
+// NewSliceBoard allocates h independent rows of w cells
+func NewSliceBoard(w, h int) SliceBoard {
+	b := make(SliceBoard, h)
+	for y := range b {
+		b[y] = make([]bool, w)
+	}
+	return b
+}
+
+// SharedRows is the classic mistake: one row allocated, h headers
+// pointing at it. Setting a cell sets the whole column.
+func SharedRows(w, h int) SliceBoard {
+	row := make([]bool, w)
+	b := make(SliceBoard, h)
+	for y := range b {
+		b[y] = row
+	}
+	return b
+}
+
+func (b SliceBoard) Width() int               { return len(b[0]) }
+func (b SliceBoard) Height() int              { return len(b) }
+func (b SliceBoard) Alive(x, y int) bool      { return b[wrap(y, len(b))][wrap(x, len(b[0]))] }
+func (b SliceBoard) Set(x, y int, alive bool) { b[wrap(y, len(b))][wrap(x, len(b[0]))] = alive }
+
+// StepSlice writes the next generation of src into dst
+func StepSlice(dst, src SliceBoard) {
+	h, w := len(src), len(src[0])
+	for y := range h {
+		above, row, below := src[wrap(y-1, h)], src[y], src[wrap(y+1, h)]
+		for x := range w {
+			left, right := wrap(x-1, w), wrap(x+1, w)
+			n := 0
+			for _, c := range [8]bool{
+				above[left], above[x], above[right],
+				row[left], row[right],
+				below[left], below[x], below[right],
+			} {
+				if c {
+					n++
+				}
+			}
+			dst[y][x] = rule(row[x], n)
+		}
+	}
+}
+
+// ShallowCopy copies the row HEADERS only: the copy shares every row
+// with b, so writing to it writes to b
+func ShallowCopy(b SliceBoard) SliceBoard {
+	out := make(SliceBoard, len(b))
+	copy(out, b)
+	return out
+}
+
+// Clone copies every cell
+func Clone(b SliceBoard) SliceBoard {
+	out := make(SliceBoard, len(b))
+	for y, row := range b {
+		out[y] = append([]bool(nil), row...)
+	}
+	return out
+}
+
+// ----------------------------
+// 3. Flat slice
+// ----------------------------
+
+// FlatBoard keeps the rows one after another in a single slice:
+// cell (x, y) is Cells[y*W+x]
+type FlatBoard struct {
+	W, H  int
+	Cells []bool
+}
+
+// NewFlatBoard allocates w*h cells in one block
+func NewFlatBoard(w, h int) *FlatBoard {
+	return &FlatBoard{W: w, H: h, Cells: make([]bool, w*h)}
+}
+
+func (b *FlatBoard) Width() int               { return b.W }
+func (b *FlatBoard) Height() int              { return b.H }
+func (b *FlatBoard) Alive(x, y int) bool      { return b.Cells[wrap(y, b.H)*b.W+wrap(x, b.W)] }
+func (b *FlatBoard) Set(x, y int, alive bool) { b.Cells[wrap(y, b.H)*b.W+wrap(x, b.W)] = alive }
+
+// StepFlat writes the next generation of src into dst, row by row: the
+// order the cells are laid out in memory
+func StepFlat(dst, src *FlatBoard) {
+	for y := range src.H {
+		stepFlatRow(dst, src, y)
+	}
+}
+
+// stepFlatRow computes row y of the next generation
+func stepFlatRow(dst, src *FlatBoard, y int) {
+	w, h, cells := src.W, src.H, src.Cells
+	up, mid, down := wrap(y-1, h)*w, y*w, wrap(y+1, h)*w
+	for x := range w {
+		left, right := wrap(x-1, w), wrap(x+1, w)
+		n := 0
+		for _, c := range [8]bool{
+			cells[up+left], cells[up+x], cells[up+right],
+			cells[mid+left], cells[mid+right],
+			cells[down+left], cells[down+x], cells[down+right],
+		} {
+			if c {
+				n++
+			}
+		}
+		dst.Cells[mid+x] = rule(cells[mid+x], n)
+	}
+}
+
+// StepFlatByColumn computes the same generation column by column. Every
+// step jumps W cells ahead in memory, so it uses the CPU cache badly.
+func StepFlatByColumn(dst, src *FlatBoard) {
+	w, h, cells := src.W, src.H, src.Cells
+	for x := range w {
+		left, right := wrap(x-1, w), wrap(x+1, w)
+		for y := range h {
+			up, mid, down := wrap(y-1, h)*w, y*w, wrap(y+1, h)*w
+			n := 0
+			for _, c := range [8]bool{
+				cells[up+left], cells[up+x], cells[up+right],
+				cells[mid+left], cells[mid+right],
+				cells[down+left], cells[down+x], cells[down+right],
+			} {
+				if c {
+					n++
+				}
+			}
+			dst.Cells[mid+x] = rule(cells[mid+x], n)
+		}
+	}
+}
+
+// ----------------------------
+// 4. Patterns, rendering, comparing
+// ----------------------------
+
+// Patterns are drawn with '#' for a live cell
+var (
+	Blinker = []string{"###"}
+	Block   = []string{"##", "##"}
+	Glider  = []string{".#.", "..#", "###"}
+)
+
+// Place draws pattern on b with its top-left corner at (x, y)
+func Place(b Board, pattern []string, x, y int) {
+	for dy, line := range pattern {
+		for dx, c := range line {
+			b.Set(x+dx, y+dy, c == '#')
+		}
+	}
+}
+
+// Render draws b with '#' and '.', one line per row
+func Render(b Board) string {
+	var s strings.Builder
+	for y := range b.Height() {
+		for x := range b.Width() {
+			if b.Alive(x, y) {
+				s.WriteByte('#')
+			} else {
+				s.WriteByte('.')
+			}
+		}
+		s.WriteByte('\n')
+	}
+	return s.String()
+}
+
+// Population counts the live cells
+func Population(b Board) int {
+	n := 0
+	for y := range b.Height() {
+		for x := range b.Width() {
+			if b.Alive(x, y) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Equal reports whether a and b have the same size and cells
+func Equal(a, b Board) bool {
+	if a.Width() != b.Width() || a.Height() != b.Height() {
+		return false
+	}
+	for y := range a.Height() {
+		for x := range a.Width() {
+			if a.Alive(x, y) != b.Alive(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+package life
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// flatFrom builds a w*h FlatBoard with pattern at (x, y)
+func flatFrom(w, h int, pattern []string, x, y int) *FlatBoard {
+	b := NewFlatBoard(w, h)
+	Place(b, pattern, x, y)
+	return b
+}
+
+// soup fills b with random cells, the same ones for the same seed
+func soup(b Board, seed uint64) {
+	r := rand.New(rand.NewPCG(seed, seed))
+	for y := range b.Height() {
+		for x := range b.Width() {
+			b.Set(x, y, r.IntN(4) == 0)
+		}
+	}
+}
+
+func TestBlinker(t *testing.T) {
+	cur := flatFrom(5, 5, Blinker, 1, 2) // horizontal ###
+	next := NewFlatBoard(5, 5)
+	StepFlat(next, cur)
+	if want := flatFrom(5, 5, []string{"#", "#", "#"}, 2, 1); !Equal(next, want) {
+		t.Errorf("generation 1:\n%swant vertical:\n%s", Render(next), Render(want))
+	}
+	cur, next = next, cur
+	StepFlat(next, cur)
+	if want := flatFrom(5, 5, Blinker, 1, 2); !Equal(next, want) {
+		t.Errorf("generation 2:\n%swant the start again:\n%s", Render(next), Render(want))
+	}
+}
+
+func TestBlock(t *testing.T) {
+	block := flatFrom(4, 4, Block, 1, 1)
+	after := NewFlatBoard(4, 4)
+	StepFlat(after, block)
+	if !Equal(after, block) {
+		t.Errorf("a block is a still life, got:\n%s", Render(after))
+	}
+}
+
+func TestGlider(t *testing.T) {
+	g, buf := flatFrom(8, 8, Glider, 1, 1), NewFlatBoard(8, 8)
+	for range 4 {
+		StepFlat(buf, g)
+		g, buf = buf, g
+	}
+	if want := flatFrom(8, 8, Glider, 2, 2); !Equal(g, want) {
+		t.Errorf("after 4 generations:\n%swant moved by (1, 1):\n%s", Render(g), Render(want))
+	}
+
+	// edges wrap: 8*4 = 32 generations bring it back to the start
+	for range 28 {
+		StepFlat(buf, g)
+		g, buf = buf, g
+	}
+	if want := flatFrom(8, 8, Glider, 1, 1); !Equal(g, want) {
+		t.Errorf("after 32 generations:\n%swant the start again:\n%s", Render(g), Render(want))
+	}
+}
+
+func TestLayoutsAgree(t *testing.T) {
+	const gens = 20
+	a, a2 := new(ArrayBoard), new(ArrayBoard)
+	s, s2 := NewSliceBoard(Size, Size), NewSliceBoard(Size, Size)
+	f, f2 := NewFlatBoard(Size, Size), NewFlatBoard(Size, Size)
+	c, c2 := NewFlatBoard(Size, Size), NewFlatBoard(Size, Size)
+	for _, b := range []Board{a, s, f, c} {
+		soup(b, 42)
+	}
+
+	for range gens {
+		StepArray(a2, a)
+		a, a2 = a2, a
+		StepSlice(s2, s)
+		s, s2 = s2, s
+		StepFlat(f2, f)
+		f, f2 = f2, f
+		StepFlatByColumn(c2, c)
+		c, c2 = c2, c
+	}
+	if !Equal(a, s) || !Equal(s, f) {
+		t.Error("array, slice and flat boards differ after 20 generations")
+	}
+	if !Equal(f, c) {
+		t.Error("row-by-row and column-by-column steps differ")
+	}
+	if Population(a) == 0 {
+		t.Error("the soup died out: the comparison proves nothing")
+	}
+}
+
+func TestAliasing(t *testing.T) {
+	want := NewSliceBoard(5, 5)
+	Place(want, []string{"#", "#", "#"}, 2, 1)
+
+	// one buffer: cells are overwritten before their neighbours read them
+	b := NewSliceBoard(5, 5)
+	Place(b, Blinker, 1, 2)
+	StepSlice(b, b)
+	if Equal(b, want) {
+		t.Error("StepSlice(b, b) gave the right answer: in place should break it")
+	}
+
+	// copy() on a slice of slices copies the row headers only
+	cur := NewSliceBoard(5, 5)
+	Place(cur, Blinker, 1, 2)
+	next := ShallowCopy(cur)
+	if &next[0][0] != &cur[0][0] {
+		t.Error("ShallowCopy does not share the rows")
+	}
+	StepSlice(next, cur)
+	if Equal(next, want) {
+		t.Error("stepping into a ShallowCopy gave the right answer")
+	}
+
+	cur = NewSliceBoard(5, 5)
+	Place(cur, Blinker, 1, 2)
+	next = Clone(cur)
+	StepSlice(next, cur)
+	if !Equal(next, want) {
+		t.Errorf("stepping into a Clone:\n%swant:\n%s", Render(next), Render(want))
+	}
+}
+
+func TestSharedRows(t *testing.T) {
+	shared := SharedRows(5, 5)
+	shared.Set(1, 1, true)
+	if got := Population(shared); got != 5 {
+		t.Errorf("Set(1, 1) on shared rows: population %d, want 5 (a whole column)", got)
+	}
+}
+
+func TestArrayCopyIsIndependent(t *testing.T) {
+	arr := new(ArrayBoard)
+	Place(arr, Glider, 0, 0)
+	snapshot := *arr // copies 64 KB
+	arr.Set(1, 0, false)
+	if !snapshot[0][1] || arr[0][1] {
+		t.Error("snapshot := *arr shares cells with arr")
+	}
+}
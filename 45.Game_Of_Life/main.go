@@ -0,0 +1,317 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go_projects/45.Game_Of_Life/life"
+)
+
+/*
+====================================================
+🔹 GAME OF LIFE: ARRAYS AND SLICES DOING REAL WORK
+====================================================
+
+16.Array, 18.Slice-P1 and 19.Slice-P2 use toy data. Conway's Game of
+Life needs a 2D grid, two copies of it, and a lot of neighbour reads:
+
+   - a live cell with 2 or 3 live neighbours survives,
+   - a dead cell with exactly 3 live neighbours comes alive,
+   - everything else dies (or stays dead).
+
+Every generation is computed from the previous one, so the grid is
+DOUBLE BUFFERED: read from `cur`, write into `next`, swap. Where that
+goes wrong is exactly where arrays and slices differ (section 3).
+
+Layouts (./life):
+    ArrayBoard  [256][256]bool   fixed size, one block, a VALUE
+    SliceBoard  [][]bool         a header per row, rows can be shared
+    FlatBoard   []bool, y*W+x    one block, any size
+
+The rules, the layouts and the aliasing pitfalls are checked by
+life/life_test.go; this program prints them, renders and benchmarks.
+
+Run it:
+    go run ./45.Game_Of_Life
+    go test ./45.Game_Of_Life/...
+    go run ./45.Game_Of_Life -animate 60      60 generations in the terminal
+    go run ./45.Game_Of_Life -benchtime=500ms
+====================================================
+*/
+
+// show prints one observation
+func show(name, got string) {
+	fmt.Printf("  %-42s %s\n", name, got)
+}
+
+// flatFrom builds a w*h FlatBoard with pattern at (x, y)
+func flatFrom(w, h int, pattern []string, x, y int) *life.FlatBoard {
+	b := life.NewFlatBoard(w, h)
+	life.Place(b, pattern, x, y)
+	return b
+}
+
+// ----------------------------
+// 1. The rules
+// ----------------------------
+func rules() {
+	fmt.Println("🔹 1. Blinker, block and glider behave as expected")
+
+	cur := flatFrom(5, 5, life.Blinker, 1, 2) // horizontal ###
+	next := life.NewFlatBoard(5, 5)
+	life.StepFlat(next, cur)
+	vertical := flatFrom(5, 5, []string{"#", "#", "#"}, 2, 1)
+	show("blinker turns vertical", fmt.Sprint(life.Equal(next, vertical), ": ", strings.ReplaceAll(life.Render(next), "\n", " ")))
+	cur, next = next, cur // swap: two pointers, no copy
+	life.StepFlat(next, cur)
+	show("...back to the start after 2 generations", fmt.Sprint(life.Equal(next, flatFrom(5, 5, life.Blinker, 1, 2))))
+
+	block := flatFrom(4, 4, life.Block, 1, 1)
+	after := life.NewFlatBoard(4, 4)
+	life.StepFlat(after, block)
+	show("block unchanged after 1 generation", fmt.Sprint(life.Equal(after, block)))
+
+	// a glider moves one cell right and one down every 4 generations
+	g := flatFrom(8, 8, life.Glider, 1, 1)
+	buf := life.NewFlatBoard(8, 8)
+	for range 4 {
+		life.StepFlat(buf, g)
+		g, buf = buf, g
+	}
+	show("glider after 4 generations moved (1, 1)", fmt.Sprint(life.Equal(g, flatFrom(8, 8, life.Glider, 2, 2)),
+		", population=", life.Population(g)))
+
+	// edges wrap: 8*4 = 32 generations bring it back to the start
+	for range 28 {
+		life.StepFlat(buf, g)
+		g, buf = buf, g
+	}
+	show("...back at (1, 1) after 32 (8x8 torus)", fmt.Sprint(life.Equal(g, flatFrom(8, 8, life.Glider, 1, 1))))
+	fmt.Println()
+}
+
+// soup fills b with random cells, the same ones for the same seed
+func soup(b life.Board, seed uint64) {
+	r := rand.New(rand.NewPCG(seed, seed))
+	for y := range b.Height() {
+		for x := range b.Width() {
+			b.Set(x, y, r.IntN(4) == 0)
+		}
+	}
+}
+
+// ----------------------------
+// 2. Three layouts, one answer
+// ----------------------------
+func layouts() {
+	fmt.Println("🔹 2. Array, slice-of-slices and flat boards agree")
+
+	const gens = 20
+	a, a2 := new(life.ArrayBoard), new(life.ArrayBoard) // 64 KB each: new, not a local value
+	s, s2 := life.NewSliceBoard(life.Size, life.Size), life.NewSliceBoard(life.Size, life.Size)
+	f, f2 := life.NewFlatBoard(life.Size, life.Size), life.NewFlatBoard(life.Size, life.Size)
+	c, c2 := life.NewFlatBoard(life.Size, life.Size), life.NewFlatBoard(life.Size, life.Size)
+	for _, b := range []life.Board{a, s, f, c} {
+		soup(b, 42)
+	}
+	start := life.Population(a)
+
+	for range gens {
+		life.StepArray(a2, a)
+		a, a2 = a2, a // swapping POINTERS to the arrays
+		life.StepSlice(s2, s)
+		s, s2 = s2, s
+		life.StepFlat(f2, f)
+		f, f2 = f2, f
+		life.StepFlatByColumn(c2, c)
+		c, c2 = c2, c
+	}
+	detail := fmt.Sprintf("population %d → %d", start, life.Population(a))
+	show(fmt.Sprintf("%dx%d soup, %d generations", life.Size, life.Size, gens), detail)
+	show("array == slice == flat", fmt.Sprint(life.Equal(a, s) && life.Equal(s, f)))
+	show("row-by-row == column-by-column", fmt.Sprint(life.Equal(f, c))) // same cells, different memory order
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. Aliasing pitfalls
+// ----------------------------
+func pitfalls() {
+	fmt.Println("🔹 3. Double buffering: where aliasing bites")
+
+	want := life.NewSliceBoard(5, 5)
+	life.Place(want, []string{"#", "#", "#"}, 2, 1)
+
+	// a) one buffer: cells are overwritten before their neighbours read them
+	b := life.NewSliceBoard(5, 5)
+	life.Place(b, life.Blinker, 1, 2)
+	life.StepSlice(b, b)
+	show("StepSlice(b, b): in place", fmt.Sprint("correct=", life.Equal(b, want), " population=", life.Population(b), " (want 3)"))
+
+	// b) copy() on a slice of slices copies the row headers only
+	cur := life.NewSliceBoard(5, 5)
+	life.Place(cur, life.Blinker, 1, 2)
+	next := life.ShallowCopy(cur)
+	show("ShallowCopy shares the rows", fmt.Sprint("&next[0][0] == &cur[0][0]: ", &next[0][0] == &cur[0][0]))
+	life.StepSlice(next, cur)
+	show("...so stepping into it is in place again", fmt.Sprint("correct=", life.Equal(next, want), " population=", life.Population(next)))
+
+	cur = life.NewSliceBoard(5, 5)
+	life.Place(cur, life.Blinker, 1, 2)
+	next = life.Clone(cur)
+	life.StepSlice(next, cur)
+	show("Clone copies every cell", fmt.Sprint("correct=", life.Equal(next, want), " population=", life.Population(next)))
+
+	// c) one row allocated, shared by every row header
+	shared := life.SharedRows(5, 5)
+	shared.Set(1, 1, true)
+	show("SharedRows: Set(1, 1) sets a whole column", strings.ReplaceAll(life.Render(shared), "\n", " "))
+
+	// d) an array is a value: `copy := *board` is a real, independent copy
+	arr := new(life.ArrayBoard)
+	life.Place(arr, life.Glider, 0, 0)
+	snapshot := *arr // copies 64 KB
+	arr.Set(1, 0, false)
+	show("snapshot := *arr; arr.Set(1, 0, false)", fmt.Sprint("snapshot[0][1]=", snapshot[0][1], " arr[0][1]=", arr[0][1]))
+	fmt.Println()
+}
+
+// ----------------------------
+// 4. Rendering
+// ----------------------------
+func render() {
+	fmt.Println("🔹 4. A glider, generation 0 to 3")
+
+	g := flatFrom(6, 6, life.Glider, 1, 1)
+	buf := life.NewFlatBoard(6, 6)
+	frames := make([][]string, 4)
+	for i := range frames {
+		frames[i] = strings.Split(strings.TrimSuffix(life.Render(g), "\n"), "\n")
+		life.StepFlat(buf, g)
+		g, buf = buf, g
+	}
+	for row := range frames[0] {
+		line := "       "
+		for _, frame := range frames {
+			line += " " + frame[row] + "  "
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+// animate shows n generations of random soup in the terminal
+func animate(n int) {
+	const w, h = 60, 20
+	cur, next := life.NewFlatBoard(w, h), life.NewFlatBoard(w, h)
+	soup(cur, uint64(time.Now().UnixNano()))
+	life.Place(cur, life.Glider, 1, 1)
+	for gen := range n {
+		fmt.Print("\033[H\033[2J") // cursor home, clear screen
+		fmt.Printf("generation %d, population %d\n", gen, life.Population(cur))
+		fmt.Print(strings.NewReplacer("#", "█", ".", " ").Replace(life.Render(cur)))
+		life.StepFlat(next, cur)
+		cur, next = next, cur
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// ----------------------------
+// 5. Benchmarks
+// ----------------------------
+func benchmarks() {
+	fmt.Printf("🔹 5. One generation of a %dx%d board, per layout\n", life.Size, life.Size)
+
+	a, a2 := new(life.ArrayBoard), new(life.ArrayBoard)
+	s, s2 := life.NewSliceBoard(life.Size, life.Size), life.NewSliceBoard(life.Size, life.Size)
+	f, f2 := life.NewFlatBoard(life.Size, life.Size), life.NewFlatBoard(life.Size, life.Size)
+	for _, b := range []life.Board{a, s, f} {
+		soup(b, 7)
+	}
+	var av, av2 life.ArrayBoard
+	// a flat board can be any size: 1024x1024 (1 MB) no longer fits in L2
+	const big = 1024
+	g, g2 := life.NewFlatBoard(big, big), life.NewFlatBoard(big, big)
+	soup(g, 7)
+
+	cases := []struct {
+		name string
+		fn   func()
+	}{
+		{"StepArray          [Size][Size]bool", func() { life.StepArray(a2, a) }},
+		{"StepSlice          [][]bool", func() { life.StepSlice(s2, s) }},
+		{"StepFlat           []bool, by row", func() { life.StepFlat(f2, f) }},
+		{"StepFlatByColumn   []bool, by column", func() { life.StepFlatByColumn(f2, f) }},
+		{"StepFlat           1024x1024, by row", func() { life.StepFlat(g2, g) }},
+		{"StepFlatByColumn   1024x1024, by column", func() { life.StepFlatByColumn(g2, g) }},
+		{"swap *ArrayBoard   (pointers)", func() { a, a2 = a2, a }},
+		{"swap ArrayBoard    (values, 3 x 64 KB)", func() { av, av2 = av2, av }},
+	}
+
+	fmt.Printf("  %-42s %12s %10s\n", "operation", "ns/op", "allocs/op")
+	for _, c := range cases {
+		r := testing.Benchmark(func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.fn()
+			}
+		})
+		ns := float64(r.T.Nanoseconds()) / float64(r.N)
+		fmt.Printf("  %-42s %12.0f %10d\n", c.name, ns, r.AllocsPerOp())
+	}
+	fmt.Println()
+}
+
+func main() {
+	testing.Init()
+	benchtime := flag.String("benchtime", "200ms", "time spent on each benchmark")
+	frames := flag.Int("animate", 0, "animate this many generations in the terminal, then exit")
+	flag.Parse()
+	if err := flag.Set("test.benchtime", *benchtime); err != nil {
+		fmt.Fprintln(os.Stderr, "invalid -benchtime:", err)
+		os.Exit(2)
+	}
+	if *frames > 0 {
+		animate(*frames)
+		return
+	}
+
+	rules()
+	layouts()
+	pitfalls()
+	render()
+	benchmarks()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) Never compute a generation in place: a cell would read neighbours
+   that were already updated. Read one buffer, write the other, swap.
+
+2) Swapping must be cheap AND must not share memory:
+     slices / pointers   cur, next = next, cur    → swaps headers
+     array values        cur, next = next, cur    → copies everything
+   copy(next, cur) on a [][]bool copies ROW HEADERS, so next and cur
+   still share every row. Clone the rows, or use a flat or array layout.
+
+3) A slice of slices can alias itself (SharedRows): one make() for the
+   row, reused for every line. An array or a flat slice cannot.
+
+4) Memory layout:
+     [N][N]bool and []bool  → one block, rows back to back
+     [][]bool               → a header per row + separate row blocks
+   Walking the cells in memory order (row by row) keeps the CPU cache
+   busy with useful bytes; walking by column jumps W bytes each step.
+   At 256x256 (64 KB) everything fits in the cache and the order
+   hardly matters; at 1024x1024 the column walk is clearly slower, and
+   the gap keeps growing with the board. The array's length is a
+   compile-time constant, so its wrap-around arithmetic is cheaper.
+====================================================
+*/
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go_projects/46.Maps/safemap"
+)
+
+/*
+====================================================
+🔹 MAPS: WHAT THE SYNTAX DOES NOT TELL YOU
+====================================================
+
+After slices (18, 19) the next built-in container is the map:
+
+    ages := map[string]int{"sadik": 25}
+    ages["rafi"] = 30          // insert or update
+    age, ok := ages["nobody"]  // 0, false
+    delete(ages, "rafi")
+
+   1. range order is random, on purpose
+   2. the zero value is a nil map: reads work, writes PANIC
+   3. map[K]Struct vs map[K]*Struct
+   4. deleting while ranging is allowed
+   5. growth: every resize allocates; make(map, n) avoids it
+   6. two goroutines writing = "fatal error: concurrent map writes",
+      which recover() cannot catch (shown in a subprocess)
+   7. the fixes: a mutex around the map (./safemap) or sync.Map
+
+The lines that do not compile (m[k].Field = v, &m[k]) are fixtures in
+43.Compile_Fixtures/fixture/testdata/maps.go.
+
+The expected results are tests in main_test.go and
+safemap/safemap_test.go (the crash included, also in a subprocess).
+
+Run it:
+    go run ./46.Maps
+    go test ./46.Maps/...
+====================================================
+*/
+
+// show prints one observation
+func show(name, got string) {
+	fmt.Printf("  %-42s %s\n", name, got)
+}
+
+// crashEnv makes this binary run the concurrent-writes demo instead of
+// the lesson
+const crashEnv = "MAPS_CRASH"
+
+// ----------------------------
+// 1. Iteration order
+// ----------------------------
+func iterationOrder() {
+	fmt.Println("🔹 1. range over a map: a different order every time")
+
+	m := map[string]int{}
+	for i := range 10 {
+		m[fmt.Sprint("k", i)] = i
+	}
+
+	orders := map[string]bool{}
+	for range 50 {
+		var keys []string
+		for k := range m {
+			keys = append(keys, k)
+		}
+		orders[strings.Join(keys, ",")] = true
+	}
+	show("50 ranges over the same map", fmt.Sprint(len(orders), " different orders"))
+
+	// fmt sorts map keys, so printing looks stable — the map is not
+	show("fmt.Sprint sorts the keys", fmt.Sprint(map[int]string{3: "c", 1: "a", 2: "b"}))
+
+	// need an order? sort the keys
+	keys := slices.Sorted(maps.Keys(m))
+	show("slices.Sorted(maps.Keys(m))", strings.Join(keys, ","))
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. Zero value and nil maps
+// ----------------------------
+
+// write stores into m and returns the panic message, if any
+func write(m map[string]int, k string, v int) (panicMsg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicMsg = fmt.Sprint(r)
+		}
+	}()
+	m[k] = v
+	return ""
+}
+
+func nilMaps() {
+	fmt.Println("🔹 2. The zero value of a map is nil: read-only")
+
+	var m map[string]int // nil: no hash table allocated
+	v, ok := m["x"]
+	show("read a nil map", fmt.Sprintf("v=%d ok=%v len=%d", v, ok, len(m)))
+	delete(m, "x") // no-op
+	for range m {  // zero iterations
+	}
+	show("delete / range on a nil map", fmt.Sprint("no panic, m == nil: ", m == nil))
+	msg := write(m, "x", 1)
+	show("write to a nil map panics", msg)
+
+	m = map[string]int{} // or make(map[string]int)
+	msg = write(m, "x", 1)
+	show("write after make / literal", fmt.Sprint(m, " panic=", msg != ""))
+
+	// the zero value hides missing keys: comma-ok tells them apart
+	stock := map[string]int{"apples": 0}
+	_, hasApples := stock["apples"]
+	_, hasPears := stock["pears"]
+	show("stock[\"apples\"], stock[\"pears\"]", fmt.Sprint(stock["apples"], ", ", stock["pears"], " (same zero...)"))
+	show("...but only apples exists", fmt.Sprintf("apples=%v pears=%v", hasApples, hasPears))
+
+	// a missing key's zero value is often exactly what you want
+	counts := map[rune]int{}
+	for _, r := range "mississippi" {
+		counts[r]++ // counts[r] = counts[r] + 1, starting at 0
+	}
+	show("counting with m[k]++", fmt.Sprint(counts))
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. Structs vs pointers
+// ----------------------------
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func structsVsPointers() {
+	fmt.Println("🔹 3. map[K]user vs map[K]*user")
+
+	byValue := map[string]user{"sadik": {"Sadik", 25}}
+	// byValue["sadik"].Age++ does not compile: the element is not addressable
+	u := byValue["sadik"] // a copy
+	u.Age++
+	show("changing the copy", fmt.Sprint("map still has ", byValue["sadik"].Age))
+	byValue["sadik"] = u // write the copy back
+	show("read, modify, write back", fmt.Sprint(byValue["sadik"].Age))
+
+	byPointer := map[string]*user{"sadik": {"Sadik", 25}}
+	byPointer["sadik"].Age++
+	show("pointer element: change in place", fmt.Sprint(byPointer["sadik"].Age))
+
+	// ...and every holder of the pointer sees the change
+	p := byPointer["sadik"]
+	p.Name = "Changed"
+	show("pointers are shared", byPointer["sadik"].Name)
+
+	// a missing key gives the zero value: nil for pointers
+	missing := byPointer["nobody"]
+	show("missing *user is nil: check before use", fmt.Sprint(missing))
+	fmt.Println()
+}
+
+// ----------------------------
+// 4. Deleting during range
+// ----------------------------
+func deleteDuringRange() {
+	fmt.Println("🔹 4. delete inside range is safe")
+
+	m := map[int]bool{}
+	for i := range 100 {
+		m[i] = true
+	}
+	for k := range m {
+		if k%2 == 0 {
+			delete(m, k) // deleting the CURRENT key
+		}
+	}
+	show("delete every even key while ranging", fmt.Sprint("len=", len(m)))
+
+	// an entry deleted before the loop reaches it is never produced
+	for i := range 100 {
+		m[i] = true
+	}
+	visits := 0
+	for range m {
+		visits++
+		clear(m) // deletes all entries, including the ones not reached yet
+	}
+	show("clear(m) in the first iteration", fmt.Sprint("visits=", visits))
+
+	// entries ADDED during range may or may not be produced: a loop that
+	// inserts while ranging can visit them or not — never rely on it
+	fmt.Println()
+}
+
+// ----------------------------
+// 5. Growth
+// ----------------------------
+func growth() {
+	fmt.Println("🔹 5. Growth: allocations while filling a map")
+
+	const n = 1000
+	fill := func(m map[int]int) {
+		for i := range n {
+			m[i] = i
+		}
+	}
+	grown := testing.AllocsPerRun(20, func() { fill(make(map[int]int)) })
+	sized := testing.AllocsPerRun(20, func() { fill(make(map[int]int, n)) })
+	show(fmt.Sprintf("make(map) then %d inserts: resizes", n), fmt.Sprintf("%.0f allocs", grown))
+	show(fmt.Sprintf("make(map, %d): sized once", n), fmt.Sprintf("%.0f allocs", sized))
+
+	// clear keeps the table: refilling it allocates nothing
+	m := make(map[int]int)
+	fill(m)
+	refill := testing.AllocsPerRun(20, func() {
+		clear(m)
+		fill(m)
+	})
+	show("clear(m) then refill", fmt.Sprintf("%.0f allocs", refill))
+
+	// the flip side: a map never shrinks. Deleting every key keeps the
+	// memory; copy the survivors to a new map to give it back.
+	fmt.Println()
+}
+
+// ----------------------------
+// 6. Concurrent writes: a fatal error
+// ----------------------------
+
+// concurrentWrites lets two goroutines write one map. The runtime
+// detects it and kills the process; recover does not help. Detection is
+// best effort: one write must see another one in progress. Map writes
+// are never preempted by the Go scheduler, so the goroutines must run
+// on separate threads (crashDemo sets GOMAXPROCS) and keep writing for
+// up to 5 seconds.
+func concurrentWrites() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("Recovered from:", r) // never printed
+		}
+	}()
+
+	m := map[int]int{}
+	deadline := time.Now().Add(5 * time.Second)
+	var wg sync.WaitGroup
+	for g := range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i%1024 != 0 || time.Now().Before(deadline); i++ {
+				m[i%10_000] = g
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Println("no crash detected this time")
+}
+
+func crashDemo() {
+	fmt.Println("🔹 6. Two goroutines, one map: the process dies")
+
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Println("  cannot locate own binary:", err)
+		return
+	}
+	var out bytes.Buffer
+	cmd := exec.Command(exe)
+	// several Ps, even on a 1-CPU machine: the OS then switches threads
+	// in the middle of a map write
+	cmd.Env = append(os.Environ(), crashEnv+"=1", "GOMAXPROCS=4")
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err = cmd.Run()
+
+	first, _, _ := strings.Cut(out.String(), "\n")
+	show("subprocess", fmt.Sprint(err))
+	show("first line of its output", fmt.Sprintf("%q", first))
+	show("recover() ran", fmt.Sprint(strings.Contains(out.String(), "Recovered from"))) // fatal errors are not panics
+	fmt.Println()
+}
+
+// ----------------------------
+// 7. The fixes
+// ----------------------------
+func fixes() {
+	fmt.Println("🔹 7. Fixes: a mutex-guarded map, or sync.Map")
+
+	const goroutines, perG = 8, 1000
+	var wg sync.WaitGroup
+
+	// safemap: a plain map behind a RWMutex
+	counts := safemap.New[string, int]()
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perG {
+				counts.Update("hits", func(n int) int { return n + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+	hits, _ := counts.Get("hits")
+	show("safemap.Update from 8 goroutines", fmt.Sprint("hits=", hits))
+
+	// sync.Map: built for keys written once and read many times, or
+	// goroutines working on DIFFERENT keys
+	var sm sync.Map
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perG {
+				sm.Store(g*perG+i, g)
+			}
+		}()
+	}
+	wg.Wait()
+	stored := 0
+	sm.Range(func(_, _ any) bool {
+		stored++
+		return true
+	})
+	show("sync.Map.Store from 8 goroutines", fmt.Sprint("entries=", stored))
+
+	// a shared counter in sync.Map: LoadOrStore one *atomic.Int64, then
+	// Add to it. Load + Store would lose updates.
+	var counters sync.Map
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perG {
+				c, _ := counters.LoadOrStore("hits", new(atomic.Int64))
+				c.(*atomic.Int64).Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	c, _ := counters.Load("hits")
+	show("sync.Map + atomic counter", fmt.Sprint("hits=", c.(*atomic.Int64).Load()))
+	fmt.Println()
+}
+
+func main() {
+	if os.Getenv(crashEnv) != "" {
+		concurrentWrites()
+		return
+	}
+
+	iterationOrder()
+	nilMaps()
+	structsVsPointers()
+	deleteDuringRange()
+	growth()
+	crashDemo()
+	fixes()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) Map order is randomized on every range so nobody depends on it.
+   Sort the keys (slices.Sorted(maps.Keys(m))) when order matters.
+
+2) var m map[K]V is nil: len, read, delete and range work, a write
+   panics. Initialize with make or a literal. Use `v, ok := m[k]` when
+   the zero value is also a valid value.
+
+3) map[K]Struct: elements are copies and not addressable; update with
+   read-modify-write. map[K]*Struct: update in place, but every holder
+   shares the struct and a missing key returns nil.
+
+4) Deleting (even clear) during range is safe; entries added during
+   range may or may not be visited.
+
+5) A map grows by allocating a bigger table and moving the entries.
+   make(map[K]V, n) sizes it once. Maps never shrink.
+
+6) Maps are not safe for concurrent writes. The runtime detects it and
+   exits with "fatal error: concurrent map writes" — not a panic, so
+   recover (and 33.Safe_Go) cannot save the process. `go run -race`
+   finds the bug before the crash does.
+
+7) Fix it with a sync.Mutex / RWMutex around a plain map (the usual
+   choice, type-safe with generics), or sync.Map for write-once /
+   disjoint-key workloads.
+====================================================
+*/
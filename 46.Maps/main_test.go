@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSortedKeys(t *testing.T) {
+	m := map[string]int{"b": 2, "c": 3, "a": 1}
+	if got := slices.Sorted(maps.Keys(m)); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("slices.Sorted(maps.Keys(m)) = %q", got)
+	}
+}
+
+func TestNilMap(t *testing.T) {
+	var m map[string]int
+	if v, ok := m["x"]; v != 0 || ok || len(m) != 0 {
+		t.Errorf("read from a nil map: v=%d ok=%v len=%d", v, ok, len(m))
+	}
+	delete(m, "x") // no-op, must not panic
+	if msg := write(m, "x", 1); msg != "assignment to entry in nil map" {
+		t.Errorf("write to a nil map: panic %q", msg)
+	}
+
+	m = map[string]int{}
+	if msg := write(m, "x", 1); msg != "" || m["x"] != 1 {
+		t.Errorf("write after make: panic %q, m=%v", msg, m)
+	}
+}
+
+func TestCommaOk(t *testing.T) {
+	stock := map[string]int{"apples": 0}
+	_, hasApples := stock["apples"]
+	_, hasPears := stock["pears"]
+	if stock["apples"] != stock["pears"] || !hasApples || hasPears {
+		t.Errorf("apples=%v pears=%v: only comma-ok tells them apart", hasApples, hasPears)
+	}
+
+	counts := map[rune]int{}
+	for _, r := range "mississippi" {
+		counts[r]++
+	}
+	if counts['s'] != 4 || counts['p'] != 2 || counts['i'] != 4 || counts['m'] != 1 {
+		t.Errorf("counts = %v", counts)
+	}
+}
+
+func TestStructsVsPointers(t *testing.T) {
+	byValue := map[string]user{"sadik": {"Sadik", 25}}
+	u := byValue["sadik"]
+	u.Age++
+	if byValue["sadik"].Age != 25 {
+		t.Error("changing a copied element changed the map")
+	}
+	byValue["sadik"] = u
+	if byValue["sadik"].Age != 26 {
+		t.Error("writing the copy back did not update the map")
+	}
+
+	byPointer := map[string]*user{"sadik": {"Sadik", 25}}
+	byPointer["sadik"].Age++
+	if byPointer["sadik"].Age != 26 {
+		t.Error("a pointer element was not changed in place")
+	}
+	if byPointer["nobody"] != nil {
+		t.Error("a missing *user is not nil")
+	}
+}
+
+func TestDeleteDuringRange(t *testing.T) {
+	m := map[int]bool{}
+	for i := range 100 {
+		m[i] = true
+	}
+	for k := range m {
+		if k%2 == 0 {
+			delete(m, k)
+		}
+	}
+	if len(m) != 50 {
+		t.Errorf("len = %d after deleting the even keys, want 50", len(m))
+	}
+
+	visits := 0
+	for range m {
+		visits++
+		clear(m)
+	}
+	if visits != 1 || len(m) != 0 {
+		t.Errorf("clear(m) in the first iteration: visits=%d len=%d, want 1 0", visits, len(m))
+	}
+}
+
+func TestGrowth(t *testing.T) {
+	const n = 1000
+	fill := func(m map[int]int) {
+		for i := range n {
+			m[i] = i
+		}
+	}
+	grown := testing.AllocsPerRun(20, func() { fill(make(map[int]int)) })
+	sized := testing.AllocsPerRun(20, func() { fill(make(map[int]int, n)) })
+	if sized >= grown {
+		t.Errorf("make(map, %d): %.0f allocs, make(map): %.0f; want fewer when sized", n, sized, grown)
+	}
+
+	m := make(map[int]int)
+	fill(m)
+	if refill := testing.AllocsPerRun(20, func() { clear(m); fill(m) }); refill != 0 {
+		t.Errorf("clear then refill: %.0f allocs, want 0", refill)
+	}
+}
+
+// TestConcurrentWrites runs concurrentWrites in a copy of the test
+// binary: the fatal error would kill this process too
+func TestConcurrentWrites(t *testing.T) {
+	if os.Getenv(crashEnv) != "" {
+		concurrentWrites()
+		return
+	}
+	if testing.Short() {
+		t.Skip("can take up to 5s")
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command(os.Args[0], "-test.run=^TestConcurrentWrites$")
+	cmd.Env = append(os.Environ(), crashEnv+"=1", "GOMAXPROCS=4")
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 2 {
+		t.Fatalf("exit = %v, want status 2; output:\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "fatal error: concurrent map") {
+		t.Errorf("output does not report concurrent map access:\n%.300s", out.String())
+	}
+	if strings.Contains(out.String(), "Recovered from") {
+		t.Error("recover() ran: a fatal error is not a panic")
+	}
+}
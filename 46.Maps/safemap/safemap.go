@@ -0,0 +1,66 @@
+// Package safemap is the mutex-guarded map of the 46.Maps lesson: a
+// plain map that many goroutines can use at once.
+package safemap
+
+import "sync"
+
+// Map is a map[K]V behind a sync.RWMutex. Readers share the lock,
+// writers take it alone. The zero value is NOT ready: use New.
+type Map[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// New returns an empty Map
+func New[K comparable, V any]() *Map[K, V] {
+	return &Map[K, V]{m: make(map[K]V)}
+}
+
+// Get returns the value for k and whether it was present
+func (s *Map[K, V]) Get(k K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[k]
+	return v, ok
+}
+
+// Set stores v under k
+func (s *Map[K, V]) Set(k K, v V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[k] = v
+}
+
+// Update replaces the value for k with fn(old) in ONE locked step.
+// Get followed by Set would let another goroutine write in between.
+func (s *Map[K, V]) Update(k K, fn func(old V) V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[k] = fn(s.m[k])
+}
+
+// Delete removes k
+func (s *Map[K, V]) Delete(k K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, k)
+}
+
+// Len returns the number of entries
+func (s *Map[K, V]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.m)
+}
+
+// Range calls fn for every entry until fn returns false. The read lock
+// is held the whole time: fn must not call Set, Update or Delete.
+func (s *Map[K, V]) Range(fn func(k K, v V) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for k, v := range s.m {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
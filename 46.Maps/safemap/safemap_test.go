@@ -0,0 +1,73 @@
+package safemap
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGetSetDelete(t *testing.T) {
+	m := New[string, int]()
+	if v, ok := m.Get("x"); v != 0 || ok {
+		t.Errorf("Get on an empty map = %d, %v; want 0, false", v, ok)
+	}
+	m.Set("x", 1)
+	m.Set("y", 0)
+	if v, ok := m.Get("y"); v != 0 || !ok {
+		t.Errorf("Get(y) = %d, %v; want 0, true: a stored zero is present", v, ok)
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+	m.Delete("x")
+	m.Delete("missing") // no-op, like delete on a plain map
+	if _, ok := m.Get("x"); ok || m.Len() != 1 {
+		t.Errorf("after Delete(x): present=%v Len()=%d, want false 1", ok, m.Len())
+	}
+}
+
+func TestUpdateConcurrent(t *testing.T) {
+	const goroutines, perG = 8, 1000
+	m := New[string, int]()
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perG {
+				m.Update("hits", func(n int) int { return n + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+	if hits, _ := m.Get("hits"); hits != goroutines*perG {
+		t.Errorf("hits = %d, want %d: Update lost writes", hits, goroutines*perG)
+	}
+}
+
+func TestRange(t *testing.T) {
+	m := New[int, int]()
+	for i := range 10 {
+		m.Set(i, i*i)
+	}
+
+	sum := 0
+	m.Range(func(k, v int) bool {
+		if v != k*k {
+			t.Errorf("Range gave %d: %d", k, v)
+		}
+		sum += v
+		return true
+	})
+	if sum != 285 {
+		t.Errorf("sum of values = %d, want 285", sum)
+	}
+
+	calls := 0
+	m.Range(func(int, int) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("Range made %d calls after fn returned false, want 1", calls)
+	}
+}
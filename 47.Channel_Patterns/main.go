@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	p "go_projects/47.Channel_Patterns/patterns"
+	"go_projects/48.Leak_Check/leakcheck"
+)
+
+/*
+====================================================
+🔹 CHANNEL PATTERNS, WITHOUT LEAKS
+====================================================
+
+So far channels only signal: 24.Process_VS_Threads ping-pongs on
+`ch := make(chan struct{})`, 23.Concurrency_VS_Parallelism waits on a
+`done` channel. Channels also CARRY DATA between stages:
+
+    Generate ──► Filter ──► Map ──► Collect            pipeline
+
+               ┌► worker ─┐
+    Generate ──┼► worker ─┼──► Merge ──► Collect        fan-out / fan-in
+               └► worker ─┘
+
+   1. generator        a goroutine that owns and closes its channel
+   2. pipeline         stages connected by channels
+   3. fan-out, fan-in  n workers on one input, merged into one output
+   4. bounded          at most n calls at once (a semaphore channel)
+   5. errgroup         first error wins and cancels the others
+
+Every pattern takes a context.Context. When the consumer stops early
+it cancels, and every goroutine upstream returns instead of blocking
+forever on a send. Each case below prints how many of the goroutines it
+started are still running (48.Leak_Check): 0. Section 6 shows a leak
+being caught. patterns/patterns_test.go checks every pattern with
+`defer leakcheck.Check(t)()`.
+
+Run it:
+    go run ./47.Channel_Patterns
+    go test ./47.Channel_Patterns/...
+====================================================
+*/
+
+// show prints one observation
+func show(name, got string) {
+	fmt.Printf("  %-42s %s\n", name, got)
+}
+
+// leftover prints how many goroutines started since before are still
+// running, and their stacks
+func leftover(name string, before leakcheck.Snapshot) {
+	leaks := before.Leaked()
+	show(name+": goroutines left", fmt.Sprint(len(leaks)))
+	for _, g := range leaks {
+		fmt.Println(g.Stack)
+	}
+}
+
+// ----------------------------
+// 1. Generator
+// ----------------------------
+func generator() {
+	fmt.Println("🔹 1. Generator: the sender owns the channel and closes it")
+
+	before := leakcheck.Take()
+	ctx := context.Background()
+	got := p.Collect(ctx, p.Generate(ctx, "a", "b", "c"))
+	show("Generate(a, b, c) → Collect", fmt.Sprint(got))
+	leftover("Generate", before)
+
+	// an endless generator stops only when the consumer cancels
+	ctx, cancel := context.WithCancel(context.Background())
+	nums := p.Collect(ctx, p.Take(ctx, p.Count(ctx, 1), 5))
+	cancel()
+	show("Take(Count(1), 5)", fmt.Sprint(nums))
+	leftover("Count + cancel", before)
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. Pipeline
+// ----------------------------
+func pipeline() {
+	fmt.Println("🔹 2. Pipeline: Generate → Filter → Map → Collect")
+
+	before := leakcheck.Take()
+	ctx := context.Background()
+	even := func(n int) bool { return n%2 == 0 }
+	square := func(n int) int { return n * n }
+
+	src := p.Generate(ctx, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	got := p.Collect(ctx, p.Map(ctx, p.Filter(ctx, src, even), square))
+	show("even squares of 1..10", fmt.Sprint(got))
+	leftover("full pipeline", before)
+
+	// the consumer wants two values only. Without the cancel, Count,
+	// Filter and Map would block forever on their next send.
+	ctx, cancel := context.WithCancel(context.Background())
+	out := p.Map(ctx, p.Filter(ctx, p.Count(ctx, 1), even), square)
+	first, second := <-out, <-out
+	cancel()
+	show("read 2 values, then cancel", fmt.Sprint(first, " ", second))
+	leftover("abandoned pipeline", before)
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. Fan-out, fan-in
+// ----------------------------
+func fanOutIn() {
+	fmt.Println("🔹 3. Fan-out to 4 workers, fan-in with Merge")
+
+	before := leakcheck.Take()
+	ctx := context.Background()
+	items := make([]int, 20)
+	for i := range items {
+		items[i] = i + 1
+	}
+	slow := func(n int) int {
+		time.Sleep(10 * time.Millisecond) // waiting, like a network call
+		return n * 10
+	}
+
+	start := time.Now()
+	workers := p.FanOut(ctx, p.Generate(ctx, items...), 4, slow)
+	got := p.Collect(ctx, p.Merge(ctx, workers...))
+	took := time.Since(start)
+	slices.Sort(got) // Merge does not keep the order
+	show("20 items, sorted results", fmt.Sprint(len(got), " results, ", got[0], " … ", got[len(got)-1]))
+	show("4 workers: ~1/4 of 20 x 10ms", took.Round(time.Millisecond).String())
+	leftover("fan-out / fan-in", before)
+
+	// cancelled halfway: workers and forwarders all return
+	ctx, cancel := context.WithCancel(context.Background())
+	merged := p.Merge(ctx, p.FanOut(ctx, p.Count(ctx, 1), 4, slow)...)
+	<-merged
+	cancel()
+	leftover("fan-in, cancelled", before)
+	fmt.Println()
+}
+
+// ----------------------------
+// 4. Bounded parallelism
+// ----------------------------
+func bounded() {
+	fmt.Println("🔹 4. ParallelMap: at most 3 calls at once, results in order")
+
+	before := leakcheck.Take()
+	var running, peak atomic.Int32
+	double := func(ctx context.Context, n int) (int, error) {
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return n * 2, nil
+	}
+
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	got, err := p.ParallelMap(context.Background(), items, 3, double)
+	show("results, in the order of the input", fmt.Sprint(got, " err=", err))
+	show("most calls running at once", fmt.Sprint(peak.Load()))
+	leftover("ParallelMap", before)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Millisecond)
+	defer cancel()
+	_, err = p.ParallelMap(ctx, items, 3, double)
+	show("timed out after 12ms", fmt.Sprint(err))
+	leftover("ParallelMap, timed out", before)
+	fmt.Println()
+}
+
+// ----------------------------
+// 5. Errgroup-style errors
+// ----------------------------
+func errgroup() {
+	fmt.Println("🔹 5. Group: the first error cancels the rest")
+
+	before := leakcheck.Take()
+	errDisk := errors.New("disk full")
+	g, ctx := p.WithContext(context.Background())
+
+	start := time.Now()
+	var stopped atomic.Int32
+	for range 3 {
+		g.Go(func() error { // long jobs that watch ctx
+			select {
+			case <-time.After(5 * time.Second):
+				return nil
+			case <-ctx.Done():
+				stopped.Add(1)
+				return ctx.Err()
+			}
+		})
+	}
+	g.Go(func() error {
+		time.Sleep(10 * time.Millisecond)
+		return errDisk
+	})
+	err := g.Wait()
+	took := time.Since(start)
+
+	show("Wait()", fmt.Sprint(err))
+	show("context.Cause(ctx)", fmt.Sprint(context.Cause(ctx)))
+	show("the 3 long jobs", fmt.Sprintf("stopped=%d after %v", stopped.Load(), took.Round(time.Millisecond)))
+	leftover("Group", before)
+
+	// ParallelMap stops starting new calls after the first error
+	var calls atomic.Int32
+	failAt3 := func(ctx context.Context, n int) (int, error) {
+		calls.Add(1)
+		if n == 3 {
+			return 0, fmt.Errorf("item %d: %w", n, errDisk)
+		}
+		time.Sleep(5 * time.Millisecond)
+		return n, ctx.Err()
+	}
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i + 1
+	}
+	_, err = p.ParallelMap(context.Background(), items, 2, failAt3)
+	show("ParallelMap with a failing item", fmt.Sprintf("%v, %d/100 calls", err, calls.Load()))
+	leftover("ParallelMap with error", before)
+	fmt.Println()
+}
+
+// ----------------------------
+// 6. A leak, caught
+// ----------------------------
+
+// leakyCount is Count without a context: nothing can stop it
+func leakyCount() <-chan int {
+	out := make(chan int)
+	go func() {
+		for n := 1; ; n++ {
+			out <- n // blocks forever once nobody reads
+		}
+	}()
+	return out
+}
+
+func leakDemo() {
+	fmt.Println("🔹 6. The same check catches a leaking generator")
+
+	before := leakcheck.Take()
+	nums := leakyCount()
+	<-nums
+	<-nums // the consumer is done...
+	leaks := before.Leaked(leakcheck.Timeout(100 * time.Millisecond))
+	show("leakyCount: goroutines leaked", fmt.Sprint(len(leaks)))
+	if len(leaks) == 1 {
+		g := leaks[0]
+		show("... stuck in", leakcheck.Summary(leaks))
+		fmt.Println("\n  its stack:")
+		for _, line := range strings.Split(g.Stack, "\n") {
+			fmt.Println("    " + line)
+		}
+	}
+	// this one can never be released: nothing closes or drains it, and
+	// it stays parked until the process exits
+	fmt.Println()
+}
+
+func main() {
+	generator()
+	pipeline()
+	fanOutIn()
+	bounded()
+	errgroup()
+	leakDemo()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) The goroutine that SENDS on a channel owns it and closes it
+   (defer close(out)). Receivers range until it is closed.
+
+2) Every send that can block needs an exit:
+       select {
+       case out <- v:
+       case <-ctx.Done(): return
+       }
+   Without it, a consumer that stops early leaks every stage upstream.
+
+3) Fan-out = several goroutines reading ONE channel (each value goes to
+   one of them). Fan-in = Merge: one forwarder per input, a WaitGroup,
+   and a last goroutine that closes the output after wg.Wait().
+
+4) Bounded parallelism = a buffered channel as a semaphore:
+       sem <- struct{}{}   // take a slot (blocks when full)
+       <-sem               // give it back
+   Writing to results[i] from goroutine i needs no lock.
+
+5) errgroup: keep the first error (sync.Once) and cancel a shared
+   context, so the other goroutines stop instead of finishing useless
+   work. golang.org/x/sync/errgroup is the production version.
+
+6) A leak does not crash anything: the goroutine just never ends.
+   Compare the goroutines before and after (48.Leak_Check) to find it.
+====================================================
+*/
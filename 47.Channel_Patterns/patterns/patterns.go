@@ -0,0 +1,247 @@
+// Package patterns holds the channel patterns of the 47.Channel_Patterns
+// lesson: generator, pipeline stages, fan-out, fan-in, bounded
+// parallelism and errgroup-style error handling.
+//
+// Every goroutine started here ends when its input is exhausted OR when
+// its context is cancelled, whichever comes first. A goroutine that
+// blocks forever on a send nobody receives is a leak.
+package patterns
+
+import (
+	"context"
+	"sync"
+)
+
+// send delivers v unless ctx is cancelled first. Every send in this
+// package goes through it: a plain `out <- v` blocks forever once the
+// consumer stops reading.
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// ----------------------------
+// 1. Generator
+// ----------------------------
+
+// Generate sends values one by one, then closes the channel
+func Generate[T any](ctx context.Context, values ...T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, v := range values {
+			if !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Count sends start, start+1, ... forever, until ctx is cancelled. Only
+// the consumer decides when to stop, so the context is mandatory.
+func Count(ctx context.Context, start int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for n := start; send(ctx, out, n); n++ {
+		}
+	}()
+	return out
+}
+
+// ----------------------------
+// 2. Pipeline stages
+// ----------------------------
+
+// Map is a stage: it applies fn to every value of in
+func Map[In, Out any](ctx context.Context, in <-chan In, fn func(In) Out) <-chan Out {
+	out := make(chan Out)
+	go func() {
+		defer close(out)
+		for v := range in {
+			if !send(ctx, out, fn(v)) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Filter is a stage: it passes on the values keep accepts
+func Filter[T any](ctx context.Context, in <-chan T, keep func(T) bool) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for v := range in {
+			if keep(v) && !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Take passes on the first n values, then stops reading. The stages
+// before it are only released when ctx is cancelled.
+func Take[T any](ctx context.Context, in <-chan T, n int) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for i := 0; i < n; i++ {
+			v, ok := <-in
+			if !ok || !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Collect reads in until it is closed or ctx is cancelled
+func Collect[T any](ctx context.Context, in <-chan T) []T {
+	var all []T
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok {
+				return all
+			}
+			all = append(all, v)
+		case <-ctx.Done():
+			return all
+		}
+	}
+}
+
+// ----------------------------
+// 3. Fan-out, fan-in
+// ----------------------------
+
+// FanOut starts n workers that all read from in. Each value is handled
+// by exactly one worker; every worker has its own output channel.
+func FanOut[In, Out any](ctx context.Context, in <-chan In, n int, fn func(In) Out) []<-chan Out {
+	outs := make([]<-chan Out, n)
+	for i := range outs {
+		outs[i] = Map(ctx, in, fn)
+	}
+	return outs
+}
+
+// Merge forwards the values of every input to one channel and closes it
+// when all inputs are closed. Order between inputs is not kept.
+func Merge[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	for _, in := range ins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range in {
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait() // the last forwarder is done: nobody sends any more
+		close(out)
+	}()
+	return out
+}
+
+// ----------------------------
+// 4. Errgroup-style error propagation
+// ----------------------------
+
+// Group runs functions in goroutines and keeps the FIRST error, like
+// golang.org/x/sync/errgroup. Created with WithContext, the first error
+// also cancels the context so the other functions can stop early.
+type Group struct {
+	wg      sync.WaitGroup
+	sem     chan struct{} // nil = no limit
+	errOnce sync.Once
+	err     error
+	cancel  context.CancelCauseFunc
+}
+
+// WithContext returns a Group and a context that is cancelled when a
+// function fails or Wait returns
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// SetLimit allows at most n functions to run at once: Go blocks until
+// one finishes. Call it before the first Go.
+func (g *Group) SetLimit(n int) {
+	g.sem = make(chan struct{}, n)
+}
+
+// Go runs fn in a new goroutine
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{} // a free slot, or wait for one
+	}
+	g.wg.Add(1)
+	go func() {
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+			g.wg.Done()
+		}()
+		if err := fn(); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				if g.cancel != nil {
+					g.cancel(err)
+				}
+			})
+		}
+	}()
+}
+
+// Wait blocks until every function returned, and returns the first error
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(g.err)
+	}
+	return g.err
+}
+
+// ----------------------------
+// 5. Bounded parallelism
+// ----------------------------
+
+// ParallelMap calls fn for every item with at most limit calls running
+// at once, and returns the results in the order of items. The first
+// error cancels the context passed to the other calls.
+func ParallelMap[In, Out any](parent context.Context, items []In, limit int, fn func(context.Context, In) (Out, error)) ([]Out, error) {
+	g, ctx := WithContext(parent)
+	g.SetLimit(limit)
+	results := make([]Out, len(items))
+	for i, item := range items {
+		if ctx.Err() != nil {
+			break // a call failed: do not start the rest
+		}
+		g.Go(func() error {
+			out, err := fn(ctx, item)
+			results[i] = out // each goroutine writes its own index: no lock needed
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if err := parent.Err(); err != nil {
+		return nil, err // cancelled from outside: results are incomplete
+	}
+	return results, nil
+}
@@ -0,0 +1,222 @@
+package patterns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go_projects/48.Leak_Check/leakcheck"
+)
+
+var even = func(n int) bool { return n%2 == 0 }
+var square = func(n int) int { return n * n }
+
+func TestGenerate(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	ctx := context.Background()
+	if got := Collect(ctx, Generate(ctx, "a", "b", "c")); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Collect(Generate(a, b, c)) = %q", got)
+	}
+	if got := Collect(ctx, Generate[int](ctx)); got != nil {
+		t.Errorf("Collect(Generate()) = %v, want nil", got)
+	}
+}
+
+func TestTakeStopsCount(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	// an endless generator stops only when the consumer cancels
+	ctx, cancel := context.WithCancel(context.Background())
+	nums := Collect(ctx, Take(ctx, Count(ctx, 1), 5))
+	cancel()
+	if !slices.Equal(nums, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Take(Count(1), 5) = %v", nums)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	ctx := context.Background()
+	src := Generate(ctx, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	if got := Collect(ctx, Map(ctx, Filter(ctx, src, even), square)); !slices.Equal(got, []int{4, 16, 36, 64, 100}) {
+		t.Errorf("even squares of 1..10 = %v", got)
+	}
+}
+
+func TestAbandonedPipeline(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	// the consumer wants two values only: the cancel releases Count,
+	// Filter and Map, blocked on their next send
+	ctx, cancel := context.WithCancel(context.Background())
+	out := Map(ctx, Filter(ctx, Count(ctx, 1), even), square)
+	first, second := <-out, <-out
+	cancel()
+	if first != 4 || second != 16 {
+		t.Errorf("first two values = %d, %d, want 4, 16", first, second)
+	}
+}
+
+func TestFanOutMerge(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	ctx := context.Background()
+	items := make([]int, 20)
+	for i := range items {
+		items[i] = i + 1
+	}
+	var calls atomic.Int32
+	times10 := func(n int) int {
+		calls.Add(1)
+		return n * 10
+	}
+
+	got := Collect(ctx, Merge(ctx, FanOut(ctx, Generate(ctx, items...), 4, times10)...))
+	slices.Sort(got) // Merge does not keep the order
+	want := make([]int, 20)
+	for i := range want {
+		want[i] = (i + 1) * 10
+	}
+	if !slices.Equal(got, want) || calls.Load() != 20 {
+		t.Errorf("got %v after %d calls, want every item handled exactly once", got, calls.Load())
+	}
+}
+
+func TestFanOutCancelled(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	// cancelled halfway: workers and forwarders all return
+	ctx, cancel := context.WithCancel(context.Background())
+	merged := Merge(ctx, FanOut(ctx, Count(ctx, 1), 4, square)...)
+	<-merged
+	cancel()
+}
+
+func TestParallelMap(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	var running, peak atomic.Int32
+	double := func(_ context.Context, n int) (int, error) {
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return n * 2, nil
+	}
+
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	got, err := ParallelMap(context.Background(), items, 3, double)
+	if err != nil || !slices.Equal(got, []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24}) {
+		t.Errorf("ParallelMap = %v, %v; want the doubles in input order", got, err)
+	}
+	if p := peak.Load(); p < 1 || p > 3 {
+		t.Errorf("peak = %d running at once, want at most 3", p)
+	}
+}
+
+func TestParallelMapTimeout(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	slow := func(ctx context.Context, n int) (int, error) {
+		select {
+		case <-time.After(time.Second):
+			return n, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	if _, err := ParallelMap(ctx, []int{1, 2, 3, 4}, 2, slow); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestParallelMapStopsOnError(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	errDisk := errors.New("disk full")
+	var calls atomic.Int32
+	failAt3 := func(ctx context.Context, n int) (int, error) {
+		calls.Add(1)
+		if n == 3 {
+			return 0, fmt.Errorf("item %d: %w", n, errDisk)
+		}
+		time.Sleep(time.Millisecond)
+		return n, ctx.Err()
+	}
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i + 1
+	}
+	_, err := ParallelMap(context.Background(), items, 2, failAt3)
+	if !errors.Is(err, errDisk) || calls.Load() >= 100 {
+		t.Errorf("err = %v after %d/100 calls, want errDisk and the rest skipped", err, calls.Load())
+	}
+}
+
+func TestGroup(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	errDisk := errors.New("disk full")
+	g, ctx := WithContext(context.Background())
+	var stopped atomic.Int32
+	for range 3 {
+		g.Go(func() error { // long jobs that watch ctx
+			select {
+			case <-time.After(5 * time.Second):
+				return nil
+			case <-ctx.Done():
+				stopped.Add(1)
+				return ctx.Err()
+			}
+		})
+	}
+	g.Go(func() error { return errDisk })
+
+	if err := g.Wait(); !errors.Is(err, errDisk) {
+		t.Errorf("Wait() = %v, want the first error", err)
+	}
+	if cause := context.Cause(ctx); !errors.Is(cause, errDisk) {
+		t.Errorf("context.Cause(ctx) = %v, want the first error", cause)
+	}
+	if stopped.Load() != 3 {
+		t.Errorf("%d long jobs stopped on cancel, want 3", stopped.Load())
+	}
+}
+
+func TestGroupSetLimit(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	var g Group
+	g.SetLimit(2)
+	var running, peak atomic.Int32
+	for range 10 {
+		g.Go(func() error {
+			now := running.Add(1)
+			defer running.Add(-1)
+			for {
+				old := peak.Load()
+				if now <= old || peak.CompareAndSwap(old, now) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil || peak.Load() > 2 {
+		t.Errorf("Wait() = %v, peak = %d; want nil and at most 2", err, peak.Load())
+	}
+}
@@ -0,0 +1,205 @@
+// Package leakcheck finds goroutines that outlive the code that started
+// them. It compares two runtime.Stack(all) dumps: every goroutine in the
+// second dump that was not in the first, and that is not a known
+// long-lived runtime goroutine, is a leak.
+//
+// With a *testing.T (or anything with the same methods):
+//
+//	defer leakcheck.Check(t)()
+//
+// Without one, from a lesson's main:
+//
+//	snap := leakcheck.Take()
+//	run()
+//	if leaks := snap.Leaked(); len(leaks) > 0 { ... }
+package leakcheck
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TB is the part of testing.TB that Check needs; *testing.T,
+// *testing.B and *testing.F all satisfy it
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Goroutine is one entry of a runtime.Stack dump
+type Goroutine struct {
+	ID    int
+	State string // "chan send", "select", "sleep", ...
+	Top   string // the function it is in, e.g. "main.leaky.func1"
+	Stack string // the full entry, as printed by the runtime
+}
+
+func (g Goroutine) String() string { return g.Stack }
+
+// ignoredTops are goroutines that live for the whole process by design.
+// runtime.Stack already hides the runtime's own (GC workers, ...).
+var ignoredTops = []string{
+	"os/signal.signal_recv", // started by the first signal.Notify, never ends
+	"os/signal.loop",
+	"testing.(*T).Run", // the test runner waiting for subtests
+	"testing.(*T).Parallel",
+	"testing.RunTests",
+	"testing.runFuzzing",
+}
+
+// ----------------------------
+// Options
+// ----------------------------
+
+type config struct {
+	timeout time.Duration
+	ignore  []string
+}
+
+// Option changes how Leaked and Check wait and what they ignore
+type Option func(*config)
+
+// Timeout is how long leaked goroutines get to finish before they are
+// reported (default 1s). Goroutines often need a moment to notice a
+// cancelled context.
+func Timeout(d time.Duration) Option {
+	return func(c *config) { c.timeout = d }
+}
+
+// IgnoreTop ignores goroutines whose stack contains the function fn,
+// for goroutines that are known to outlive the check
+func IgnoreTop(fn string) Option {
+	return func(c *config) { c.ignore = append(c.ignore, fn) }
+}
+
+// ----------------------------
+// Snapshots
+// ----------------------------
+
+// Snapshot records which goroutines existed at one moment
+type Snapshot struct {
+	ids map[int]bool
+}
+
+// Take records the goroutines running now
+func Take() Snapshot {
+	s := Snapshot{ids: map[int]bool{}}
+	for _, g := range Goroutines() {
+		s.ids[g.ID] = true
+	}
+	return s
+}
+
+// Leaked returns the goroutines started after s that are still running.
+// It waits up to the timeout for them to end before reporting them.
+func (s Snapshot) Leaked(opts ...Option) []Goroutine {
+	c := config{timeout: time.Second}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	deadline := time.Now().Add(c.timeout)
+	for {
+		leaks := s.extra(c.ignore)
+		if len(leaks) == 0 || time.Now().After(deadline) {
+			return leaks
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// extra lists the goroutines not in s, minus the ignored ones and the
+// caller's own goroutine
+func (s Snapshot) extra(ignore []string) []Goroutine {
+	all := Goroutines()
+	var leaks []Goroutine
+	for _, g := range all[1:] { // all[0] is the goroutine calling Stack
+		if !s.ids[g.ID] && !ignored(g, ignore) {
+			leaks = append(leaks, g)
+		}
+	}
+	return leaks
+}
+
+func ignored(g Goroutine, extra []string) bool {
+	for _, list := range [][]string{ignoredTops, extra} {
+		for _, fn := range list {
+			if strings.Contains(g.Stack, "\n"+fn+"(") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check takes a snapshot now and returns the function that compares
+// against it. Call that at the end of the test, usually with defer:
+//
+//	defer leakcheck.Check(t)()
+//
+// Every leaked goroutine fails the test with its full stack.
+func Check(t TB, opts ...Option) func() {
+	t.Helper()
+	snap := Take()
+	return func() {
+		t.Helper()
+		leaks := snap.Leaked(opts...)
+		if len(leaks) == 0 {
+			return
+		}
+		var b strings.Builder
+		for _, g := range leaks {
+			b.WriteString("\n\n")
+			b.WriteString(g.Stack)
+		}
+		t.Errorf("leakcheck: %d goroutine(s) leaked:%s", len(leaks), b.String())
+	}
+}
+
+// ----------------------------
+// Parsing runtime.Stack
+// ----------------------------
+
+// header matches "goroutine 18 [chan send, 2 minutes]:"
+var header = regexp.MustCompile(`^goroutine (\d+) \[([^\],]+)`)
+
+// Goroutines returns every goroutine, the calling one first
+func Goroutines() []Goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf)) // truncated: try again, bigger
+	}
+
+	var gs []Goroutine
+	for _, entry := range strings.Split(strings.TrimSpace(string(buf)), "\n\n") {
+		m := header.FindStringSubmatch(entry)
+		if m == nil {
+			continue
+		}
+		id, _ := strconv.Atoi(m[1])
+		g := Goroutine{ID: id, State: m[2], Stack: entry}
+		if lines := strings.SplitN(entry, "\n", 3); len(lines) > 1 {
+			g.Top, _, _ = strings.Cut(lines[1], "(")
+		}
+		gs = append(gs, g)
+	}
+	return gs
+}
+
+// Summary is one line per goroutine: "#18 [chan send] main.leaky.func1"
+func Summary(gs []Goroutine) string {
+	lines := make([]string, len(gs))
+	for i, g := range gs {
+		lines[i] = fmt.Sprintf("#%d [%s] %s", g.ID, g.State, g.Top)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,189 @@
+package leakcheck
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pkg is the prefix of this package's functions in a stack
+const pkg = "go_projects/48.Leak_Check/leakcheck."
+
+// recorder is a TB that keeps the errors instead of failing the test
+type recorder struct {
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+// leaky starts a goroutine that blocks on a send nobody receives. It
+// ends only when release is closed.
+func leaky(release <-chan struct{}) {
+	out := make(chan int)
+	go func() {
+		select {
+		case out <- 1: // nobody reads out
+		case <-release:
+		}
+	}()
+}
+
+// cacheJanitor lives until stop is closed, like a background goroutine
+// meant to outlive the code that starts it
+func cacheJanitor(stop <-chan struct{}) {
+	for {
+		select {
+		case <-time.After(time.Minute):
+		case <-stop:
+			return
+		}
+	}
+}
+
+func TestGoroutines(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	leaky(release)
+	time.Sleep(10 * time.Millisecond) // let it start and block
+
+	gs := Goroutines()
+	if gs[0].State != "running" || !strings.Contains(gs[0].Stack, "\n"+pkg+"TestGoroutines(") {
+		t.Errorf("first entry is not the caller:\n%s", gs[0].Stack)
+	}
+	var found *Goroutine
+	for i, g := range gs {
+		if g.Top == pkg+"leaky.func1" {
+			found = &gs[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("leaky's goroutine not found in:\n%s", Summary(gs))
+	}
+	if found.State != "select" {
+		t.Errorf("State = %q, want select", found.State)
+	}
+	if !strings.Contains(found.Stack, "created by "+pkg+"leaky") {
+		t.Errorf("the stack does not say who started it:\n%s", found.Stack)
+	}
+	if want := fmt.Sprintf("#%d [select] %sleaky.func1", found.ID, pkg); Summary([]Goroutine{*found}) != want {
+		t.Errorf("Summary = %q, want %q", Summary([]Goroutine{*found}), want)
+	}
+}
+
+func TestCheckClean(t *testing.T) {
+	r := &recorder{}
+	func() {
+		defer Check(r)()
+		done := make(chan struct{})
+		for range 3 {
+			go func() { done <- struct{}{} }()
+		}
+		for range 3 {
+			<-done
+		}
+	}()
+	if len(r.errs) != 0 {
+		t.Errorf("goroutines that ended were reported: %q", r.errs)
+	}
+}
+
+func TestCheckLeak(t *testing.T) {
+	r := &recorder{}
+	release := make(chan struct{})
+	func() {
+		defer Check(r, Timeout(50*time.Millisecond))()
+		leaky(release)
+	}()
+	close(release)
+
+	if len(r.errs) != 1 {
+		t.Fatalf("%d errors, want 1: %q", len(r.errs), r.errs)
+	}
+	msg := r.errs[0]
+	for _, want := range []string{"1 goroutine(s) leaked", pkg + "leaky.func1()", "48.Leak_Check/leakcheck/leakcheck_test.go:"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("the error does not contain %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestGracePeriod(t *testing.T) {
+	// the goroutine ends 30ms after cancel, like a worker that finishes
+	// its current item before it looks at ctx.Done()
+	run := func(opts ...Option) int {
+		r := &recorder{}
+		finished := make(chan struct{})
+		func() {
+			defer Check(r, opts...)()
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				defer close(finished)
+				<-ctx.Done()
+				time.Sleep(30 * time.Millisecond)
+			}()
+			cancel()
+		}()
+		<-finished
+		return len(r.errs)
+	}
+
+	if n := run(); n != 0 {
+		t.Errorf("default timeout: %d errors, want 0", n)
+	}
+	if n := run(Timeout(0)); n != 1 {
+		t.Errorf("Timeout(0): %d errors, want 1", n)
+	}
+}
+
+func TestIgnoreTop(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	r := &recorder{}
+	func() {
+		defer Check(r, Timeout(20*time.Millisecond))()
+		go cacheJanitor(stop)
+	}()
+	if len(r.errs) != 1 {
+		t.Errorf("cacheJanitor: %d errors, want 1", len(r.errs))
+	}
+
+	r = &recorder{}
+	func() {
+		defer Check(r, Timeout(20*time.Millisecond), IgnoreTop(pkg+"cacheJanitor"))()
+		go cacheJanitor(stop)
+	}()
+	if len(r.errs) != 0 {
+		t.Errorf("IgnoreTop(cacheJanitor): %q", r.errs)
+	}
+}
+
+func TestSignalIgnoredByDefault(t *testing.T) {
+	// the first signal.Notify starts a goroutine that never ends, even
+	// after stop()
+	r := &recorder{}
+	func() {
+		defer Check(r, Timeout(20*time.Millisecond))()
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		stop()
+		<-ctx.Done()
+	}()
+	if len(r.errs) != 0 {
+		t.Errorf("os/signal's goroutine was reported: %q", r.errs)
+	}
+}
+
+func TestCheckWithT(t *testing.T) {
+	defer Check(t)()
+	done := make(chan struct{})
+	go close(done)
+	<-done
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"go_projects/48.Leak_Check/leakcheck"
+)
+
+/*
+====================================================
+🔹 LEAK CHECK: WHICH GOROUTINE, AND WHERE?
+====================================================
+
+47.Channel_Patterns counted goroutines with runtime.NumGoroutine(). A
+count says THAT something leaked, not WHAT. runtime.Stack(buf, true)
+prints every goroutine:
+
+    goroutine 11 [select]:
+    main.leaky.func1()
+            /src/48.Leak_Check/main.go:78 +0x5f
+    created by main.leaky in goroutine 1
+            /src/48.Leak_Check/main.go:77 +0x87
+
+leakcheck takes that dump before and after, and reports every new
+goroutine with its stack: the file and line where it is stuck, and the
+line that started it.
+
+    defer leakcheck.Check(t)()          in a test (any *testing.T)
+
+    snap := leakcheck.Take()            anywhere else
+    ...
+    leaks := snap.Leaked()
+
+   1. parsing runtime.Stack
+   2. clean code passes
+   3. a leak fails, with its stack
+   4. slow goroutines get a grace period (Timeout)
+   5. long-lived goroutines can be ignored (IgnoreTop, os/signal)
+
+This program prints what each case reports; leakcheck/leakcheck_test.go
+checks it.
+
+Run it:
+    go run ./48.Leak_Check
+    go test ./48.Leak_Check/...
+====================================================
+*/
+
+// show prints one observation
+func show(name, got string) {
+	fmt.Printf("  %-42s %s\n", name, got)
+}
+
+// recorder is a leakcheck.TB that keeps the errors instead of failing a
+// test, so the lesson can look at them
+type recorder struct {
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+// leaky starts a goroutine that blocks on a send nobody receives. It
+// ends only when release is closed.
+func leaky(release <-chan struct{}) {
+	out := make(chan int)
+	go func() {
+		select {
+		case out <- 1: // nobody reads out
+		case <-release:
+		}
+	}()
+}
+
+// ----------------------------
+// 1. Parsing runtime.Stack
+// ----------------------------
+func parsing() {
+	fmt.Println("🔹 1. Goroutines(): one entry per goroutine, caller first")
+
+	release := make(chan struct{})
+	leaky(release)
+	time.Sleep(10 * time.Millisecond) // let it start and block
+	gs := leakcheck.Goroutines()
+	close(release)
+
+	show("first entry (the caller)", leakcheck.Summary(gs[:1]))
+	var found leakcheck.Goroutine
+	for _, g := range gs {
+		if g.Top == "main.leaky.func1" {
+			found = g
+		}
+	}
+	show("leaky's goroutine", leakcheck.Summary([]leakcheck.Goroutine{found}))
+	if _, creator, ok := strings.Cut(found.Stack, "\ncreated by "); ok {
+		creator, _, _ = strings.Cut(creator, "\n")
+		show("... created by", creator)
+	}
+	fmt.Println()
+}
+
+// ----------------------------
+// 2. Clean code passes
+// ----------------------------
+func clean() {
+	fmt.Println("🔹 2. Goroutines that end are not leaks")
+
+	r := &recorder{}
+	func() {
+		defer leakcheck.Check(r)()
+		done := make(chan struct{})
+		for range 3 {
+			go func() { done <- struct{}{} }()
+		}
+		for range 3 {
+			<-done
+		}
+	}()
+	show("3 goroutines, all received from", fmt.Sprint("errors=", len(r.errs)))
+	fmt.Println()
+}
+
+// ----------------------------
+// 3. A leak fails, with its stack
+// ----------------------------
+func leak() {
+	fmt.Println("🔹 3. A leaked goroutine fails the check")
+
+	r := &recorder{}
+	release := make(chan struct{})
+	func() {
+		defer leakcheck.Check(r, leakcheck.Timeout(50*time.Millisecond))()
+		leaky(release)
+	}()
+	close(release) // let it go, so it does not show up in later sections
+
+	msg := strings.Join(r.errs, "\n")
+	show("leaky() inside Check", fmt.Sprint("errors=", len(r.errs)))
+	if len(r.errs) > 0 {
+		fmt.Println("\n  what a failing test would print:")
+		for _, line := range strings.Split(msg, "\n") {
+			fmt.Println("    " + line)
+		}
+	}
+	fmt.Println()
+}
+
+// ----------------------------
+// 4. Grace period
+// ----------------------------
+func grace() {
+	fmt.Println("🔹 4. Goroutines still shutting down get a grace period")
+
+	// the goroutine ends 30ms after cancel, like a worker that finishes
+	// its current item before it looks at ctx.Done()
+	run := func(opts ...leakcheck.Option) int {
+		r := &recorder{}
+		func() {
+			defer leakcheck.Check(r, opts...)()
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				<-ctx.Done()
+				time.Sleep(30 * time.Millisecond)
+			}()
+			cancel()
+		}()
+		time.Sleep(50 * time.Millisecond) // let a reported one finish anyway
+		return len(r.errs)
+	}
+
+	n := run()
+	show("default timeout (1s)", fmt.Sprint("errors=", n))
+	n = run(leakcheck.Timeout(0))
+	show("Timeout(0)", fmt.Sprint("errors=", n))
+	fmt.Println()
+}
+
+// ----------------------------
+// 5. Ignoring long-lived goroutines
+// ----------------------------
+
+// cacheJanitor is a background goroutine that lives as long as the
+// process on purpose
+func cacheJanitor(stop <-chan struct{}) {
+	for {
+		select {
+		case <-time.After(time.Minute):
+		case <-stop:
+			return
+		}
+	}
+}
+
+func ignore() {
+	fmt.Println("🔹 5. Goroutines meant to outlive the check")
+
+	stop := make(chan struct{})
+	r := &recorder{}
+	func() {
+		defer leakcheck.Check(r, leakcheck.Timeout(20*time.Millisecond))()
+		go cacheJanitor(stop)
+	}()
+	show("cacheJanitor, no options", fmt.Sprint("errors=", len(r.errs)))
+
+	r = &recorder{}
+	func() {
+		defer leakcheck.Check(r, leakcheck.Timeout(20*time.Millisecond), leakcheck.IgnoreTop("main.cacheJanitor"))()
+		go cacheJanitor(stop)
+	}()
+	show(`IgnoreTop("main.cacheJanitor")`, fmt.Sprint("errors=", len(r.errs)))
+	close(stop)
+
+	// the first signal.Notify starts a goroutine that never ends, even
+	// after stop(). It is on the default ignore list.
+	r = &recorder{}
+	func() {
+		defer leakcheck.Check(r, leakcheck.Timeout(20*time.Millisecond))()
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		stop()
+		<-ctx.Done()
+	}()
+	show("signal.NotifyContext, no options", fmt.Sprint("errors=", len(r.errs)))
+	fmt.Println()
+}
+
+func main() {
+	parsing()
+	clean()
+	leak()
+	grace()
+	ignore()
+}
+
+/*
+====================================================
+🔹 KEY TAKEAWAYS
+====================================================
+
+1) runtime.NumGoroutine() tells you THAT something leaked.
+   runtime.Stack(buf, true) tells you WHAT: every goroutine, its state
+   ([chan send], [select], ...), where it is stuck and who created it.
+
+2) A leak check compares goroutine IDs before and after. IDs are never
+   reused, so "new ID still alive" = started here and not finished.
+
+3) Wait a little before reporting: a cancelled goroutine needs a moment
+   to notice. Without the grace period the check is flaky.
+
+4) Some goroutines live forever by design (os/signal's receiver, a
+   cache janitor). Ignore them by function name, never by count.
+
+5) In a test file:
+       func TestPipeline(t *testing.T) {
+           defer leakcheck.Check(t)()
+           ...
+       }
+   go.uber.org/goleak is the production version of this idea.
+====================================================
+*/
@@ -9,6 +9,7 @@ var a = 10
 // - It runs **before main()** automatically.
 // - You cannot call it manually.
 // - Each package can have multiple init() functions (even in different files).
+// - The exact order (vars, inits, imported packages): see 36.Init_Order.
 func init() {
 	fmt.Println("I am the init function, I can't be called. I am automatically called")
 	fmt.Println("Value of a inside init():", a) // 10 (global value)